@@ -0,0 +1,195 @@
+package swaggo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Pieeer1/Auto-SwagGo/internal/ext"
+)
+
+// Principal is the authenticated caller returned by a verifier and stored on the request context.
+type Principal struct {
+	Subject string
+	Scopes  []string
+	Claims  map[string]any
+}
+
+// AuthVerifier validates the credential extracted from the request. Returning an error responds with a 401.
+// Basic auth credentials are passed as "username:password", bearer style credentials as the raw token and api keys as the key value.
+type AuthVerifier func(r *http.Request, credential string) (Principal, error)
+
+// AuthorizationCallback decides whether the authenticated principal may call the operation. Returning an error responds with a 403.
+type AuthorizationCallback func(r *http.Request, principal Principal, scopes []string, scopeMatch ScopeMatch) error
+
+type ScopeMatch string
+
+const (
+	AllScopes ScopeMatch = "all"
+	AnyScope  ScopeMatch = "any"
+)
+
+func (p Principal) HasScopes(scopes []string, scopeMatch ScopeMatch) bool {
+	if len(scopes) == 0 {
+		return true
+	}
+
+	granted := ext.Where(scopes, func(scope string) bool {
+		return ext.Contains(p.Scopes, scope)
+	})
+
+	if scopeMatch == AnyScope {
+		return len(granted) > 0
+	}
+
+	return len(granted) == len(scopes)
+}
+
+type principalContextKey struct{}
+
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(Principal)
+	return principal, ok
+}
+
+type authScheme struct {
+	verify     AuthVerifier
+	credential func(r *http.Request) (string, bool)
+	challenge  func(credentialPresent bool) string
+}
+
+// authenticate runs every configured verifier until one succeeds. Schemes without a verifier are documentation only,
+// so a nil principal and nil error are returned when nothing is enforced.
+func authenticate(r *http.Request, authenticationConfiguration *AuthenticationConfiguration) (*Principal, []string, error) {
+	schemes := ext.Where(getAuthSchemes(authenticationConfiguration), func(scheme authScheme) bool {
+		return scheme.verify != nil
+	})
+
+	if len(schemes) == 0 {
+		return nil, nil, nil
+	}
+
+	challenges := make([]string, 0)
+
+	for _, scheme := range schemes {
+		credential, present := scheme.credential(r)
+
+		if present {
+			principal, err := scheme.verify(r, credential)
+			if err == nil {
+				return &principal, nil, nil
+			}
+		}
+
+		challenges = append(challenges, scheme.challenge(present))
+	}
+
+	return nil, ext.Distinct(challenges), fmt.Errorf("unauthorized")
+}
+
+func getAuthSchemes(authenticationConfiguration *AuthenticationConfiguration) []authScheme {
+	schemes := make([]authScheme, 0)
+
+	if authenticationConfiguration == nil {
+		return schemes
+	}
+
+	if basicAuth := authenticationConfiguration.BasicAuth; basicAuth != nil {
+		realm := basicAuth.Realm
+		if realm == "" {
+			realm = "Restricted"
+		}
+		schemes = append(schemes, authScheme{
+			verify: basicAuth.Verify,
+			credential: func(r *http.Request) (string, bool) {
+				username, password, ok := r.BasicAuth()
+				return fmt.Sprintf("%s:%s", username, password), ok
+			},
+			challenge: func(bool) string {
+				return fmt.Sprintf(`Basic realm="%s"`, realm)
+			},
+		})
+	}
+
+	if authenticationConfiguration.BearerAuth != nil {
+		schemes = append(schemes, bearerAuthScheme(authenticationConfiguration.BearerAuth.Verify))
+	}
+
+	if authenticationConfiguration.OpenIdAuth != nil {
+		schemes = append(schemes, bearerAuthScheme(authenticationConfiguration.OpenIdAuth.Verify))
+	}
+
+	if authenticationConfiguration.Oauth2Auth != nil {
+		schemes = append(schemes, bearerAuthScheme(authenticationConfiguration.Oauth2Auth.Verify))
+	}
+
+	if apiKeyAuth := authenticationConfiguration.ApiKeyAuth; apiKeyAuth != nil {
+		name := apiKeyAuth.Name
+		if name == "" {
+			name = "apiKey"
+		}
+		in := apiKeyAuth.In
+		if in == "" {
+			in = "header"
+		}
+		schemes = append(schemes, authScheme{
+			verify: apiKeyAuth.Verify,
+			credential: func(r *http.Request) (string, bool) {
+				switch in {
+				case "query":
+					return r.URL.Query().Get(name), r.URL.Query().Has(name)
+				case "cookie":
+					cookie, err := r.Cookie(name)
+					if err != nil {
+						return "", false
+					}
+					return cookie.Value, true
+				default:
+					return r.Header.Get(name), r.Header.Get(name) != ""
+				}
+			},
+			challenge: func(bool) string {
+				return fmt.Sprintf(`ApiKey name="%s", in="%s"`, name, in)
+			},
+		})
+	}
+
+	return schemes
+}
+
+func bearerAuthScheme(verify AuthVerifier) authScheme {
+	return authScheme{
+		verify: verify,
+		credential: func(r *http.Request) (string, bool) {
+			scheme, token, found := strings.Cut(r.Header.Get("Authorization"), " ")
+			if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+				return "", false
+			}
+			return strings.TrimSpace(token), true
+		},
+		challenge: func(credentialPresent bool) string {
+			if credentialPresent {
+				return `Bearer error="invalid_token"`
+			}
+			return "Bearer"
+		},
+	}
+}
+
+func authorize(r *http.Request, requestDetails RequestDetails, principal Principal) error {
+	scopeMatch := requestDetails.OauthScopeMatch
+	if scopeMatch == "" {
+		scopeMatch = AllScopes
+	}
+
+	if requestDetails.AuthenticationConfiguration.Authorize != nil {
+		return requestDetails.AuthenticationConfiguration.Authorize(r, principal, requestDetails.OauthScopes, scopeMatch)
+	}
+
+	if !principal.HasScopes(requestDetails.OauthScopes, scopeMatch) {
+		return fmt.Errorf("missing required scopes")
+	}
+
+	return nil
+}
@@ -0,0 +1,224 @@
+package swaggo
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Bind fills a struct of type T from the given request source using the same naming rules as the generated documentation.
+// Absent values take their default. Conversion and missing required fields are reported as ValidationErrors.
+func Bind[T any](r *http.Request, source RequestDataSource) (T, error) {
+	var target T
+
+	v := reflect.ValueOf(&target).Elem()
+	withDefaults(r, source, v)
+
+	if source == BodySource {
+		if v.Kind() == reflect.Struct {
+			applyDefaults(v)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&target); err != nil {
+			return target, jsonBodyError(err)
+		}
+		return target, nil
+	}
+
+	if v.Kind() != reflect.Struct {
+		return target, fmt.Errorf("bind target must be a struct. Got %s", v.Kind().String())
+	}
+
+	if validationErrors := bindParameters(r, source, v); len(validationErrors) > 0 {
+		return target, validationErrors
+	}
+
+	return target, nil
+}
+
+// jsonBodyError reports a body that cannot be decoded as ValidationErrors. A body over the size limit keeps its *http.MaxBytesError
+// so it is answered with a 413.
+func jsonBodyError(err error) error {
+	var maxBytesError *http.MaxBytesError
+
+	if errors.As(err, &maxBytesError) {
+		return err
+	}
+
+	return ValidationErrors{{Message: fmt.Sprintf("invalid json: %s", err.Error())}}
+}
+
+func BindQuery[T any](r *http.Request) (T, error) {
+	return Bind[T](r, QuerySource)
+}
+
+func BindPath[T any](r *http.Request) (T, error) {
+	return Bind[T](r, PathSource)
+}
+
+func BindHeader[T any](r *http.Request) (T, error) {
+	return Bind[T](r, HeaderSource)
+}
+
+func bindParameters(r *http.Request, source RequestDataSource, v reflect.Value) ValidationErrors {
+	validationErrors := make(ValidationErrors, 0)
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		if !t.Field(i).IsExported() || !parameterInSource(t.Field(i), source) {
+			continue
+		}
+
+		validationErrors = append(validationErrors, bindParameter(r, source, t.Field(i), v.Field(i))...)
+	}
+
+	return validationErrors
+}
+
+func bindParameter(r *http.Request, source RequestDataSource, field reflect.StructField, v reflect.Value) ValidationErrors {
+	fName, ok := parameterName(field, namingStrategyFromContext(r.Context()))
+
+	if !ok {
+		return nil
+	}
+	values := parameterValues(r, source, fName, field.Type)
+
+	if len(values) == 0 {
+		if field.Tag.Get("required") == "true" {
+			return ValidationErrors{{Field: fName, Message: "is required"}}
+		}
+		if value, ok, err := fieldDefault(field); ok && err == nil {
+			v.Set(value)
+		}
+		return nil
+	}
+
+	if err := setParameterValue(v, values); err != nil {
+		return ValidationErrors{{Field: fName, Message: err.Error()}}
+	}
+
+	// the constraint tags were checked when the route was registered
+	constraints, _ := parseFieldConstraints(field)
+	return constraints.check(fName, constraintValue(v))
+}
+
+func parameterValues(r *http.Request, source RequestDataSource, name string, t reflect.Type) []string {
+	var values []string
+
+	switch source {
+	case QuerySource:
+		values = r.URL.Query()[name]
+	case PathSource:
+		if pathValue := r.PathValue(name); pathValue != "" {
+			values = []string{pathValue}
+		}
+	case HeaderSource:
+		values = r.Header.Values(name)
+	}
+
+	// path and header parameters use the simple style, serializing arrays as comma separated values
+	if source != QuerySource && isSliceParameter(t) {
+		splitValues := make([]string, 0)
+		for _, value := range values {
+			for _, splitValue := range strings.Split(value, ",") {
+				splitValues = append(splitValues, strings.TrimSpace(splitValue))
+			}
+		}
+		values = splitValues
+	}
+
+	return values
+}
+
+func isSliceParameter(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8
+}
+
+func setParameterValue(v reflect.Value, values []string) error {
+	if v.Kind() == reflect.Ptr {
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+
+	if isSliceParameter(v.Type()) {
+		slice := reflect.MakeSlice(v.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := setScalarValue(slice.Index(i), value); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+		return nil
+	}
+
+	return setScalarValue(v, values[0])
+}
+
+func setScalarValue(v reflect.Value, value string) error {
+	if v.Kind() == reflect.Ptr {
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+
+	if v.Type() == reflect.TypeOf(time.Time{}) {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return fmt.Errorf("must be an RFC 3339 date-time")
+		}
+		v.Set(reflect.ValueOf(parsed))
+		return nil
+	}
+
+	if unmarshaler, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		if err := unmarshaler.UnmarshalText([]byte(value)); err != nil {
+			return fmt.Errorf("must be a valid %s", v.Type().String())
+		}
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("must be a boolean")
+		}
+		v.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("must be an integer")
+		}
+		v.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(value, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("must be a non-negative integer")
+		}
+		v.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("must be a number")
+		}
+		v.SetFloat(parsed)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			return fmt.Errorf("unsupported parameter type %s", v.Type().String())
+		}
+		v.SetBytes([]byte(value))
+	default:
+		return fmt.Errorf("unsupported parameter type %s", v.Type().String())
+	}
+
+	return nil
+}
@@ -1,13 +1,13 @@
 package swaggo
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"reflect"
 	"strings"
 	"sync"
-	"time"
 
 	"github.com/Pieeer1/Auto-SwagGo/internal/ext"
 )
@@ -15,24 +15,51 @@ import (
 var IGNORED_TAGS = []string{"swagger", "openapi.json"}
 
 type SwaggoMux struct {
-	mux         *http.ServeMux
-	swaggerInfo *SwaggerInfo
-	baseUri     string
-	prefix      string
-	versions    []string
-	routes      []Route
-	mu          sync.RWMutex
+	mux                   *http.ServeMux
+	swaggerInfo           *SwaggerInfo
+	baseUri               string
+	prefix                string
+	versions              []string
+	routes                []Route
+	corsConfiguration     *CorsConfiguration
+	versionConfigurations map[string]VersionConfiguration
+	namingStrategy        NamingStrategy
+	embeddedStructMode    EmbeddedStructMode
+	openApiVersion        OpenApiVersion
+	inferRequired         bool
+	schemaNaming          SchemaNamingStrategy
+	genericNaming         GenericSchemaNamingStrategy
+	schemaAliases         map[reflect.Type]string
+	registeredSchemas     []schemaRegistration
+	maxBodySize           int64
+	mu                    sync.RWMutex
+}
+
+// DefaultMaxBodySize is the largest request body in bytes accepted unless ConfigureMaxBodySize sets another limit.
+const DefaultMaxBodySize int64 = 10 << 20
+
+type schemaRegistration struct {
+	name string
+	data any
 }
 
 func NewSwaggoMux(swaggerInfo *SwaggerInfo, baseUri, prefix string, versions []string) *SwaggoMux {
 	client := &SwaggoMux{
-		routes:      make([]Route, 0),
-		swaggerInfo: swaggerInfo,
-		baseUri:     baseUri,
-		versions:    versions,
-		prefix:      prefix,
-		mux:         http.NewServeMux(),
-		mu:          sync.RWMutex{},
+		routes:                make([]Route, 0),
+		swaggerInfo:           swaggerInfo,
+		baseUri:               baseUri,
+		versions:              versions,
+		prefix:                prefix,
+		versionConfigurations: make(map[string]VersionConfiguration),
+		namingStrategy:        FieldNameStrategy,
+		embeddedStructMode:    FlattenEmbeddedStructs,
+		openApiVersion:        OpenApi30,
+		schemaNaming:          ShortSchemaName,
+		genericNaming:         OfGenericSchemaName,
+		schemaAliases:         make(map[reflect.Type]string),
+		maxBodySize:           DefaultMaxBodySize,
+		mux:                   http.NewServeMux(),
+		mu:                    sync.RWMutex{},
 	}
 
 	client.HandleFunc("/swagger/index.html", client.swagger, "", RequestDetails{Method: "GET"})
@@ -61,25 +88,314 @@ func (m *SwaggoMux) Handle(path string, handler http.Handler, version string, re
 		fullPath = fmt.Sprintf("%s/%s%s", m.prefix, version, path)
 	}
 
-	m.routes = append(m.routes, Route{Path: fullPath, Handler: handler, Prefix: m.prefix, Version: version, RequestDetails: requestDetails})
-	m.mux.Handle(fullPath, m.defaultMiddleware(handler, requestDetails))
+	methodHandlers := make(map[string]http.Handler)
+
+	for _, rd := range requestDetails {
+		if err := checkDeprecationDate(rd.Deprecated, rd.DeprecatedAt); err != nil {
+			panic(fmt.Sprintf("swaggo: %s %s: %s", rd.Method, fullPath, err))
+		}
+		for _, request := range rd.Requests {
+			if err := checkConstraintTags(request.Data); err != nil {
+				panic(fmt.Sprintf("swaggo: %s %s: %s", rd.Method, fullPath, err))
+			}
+		}
+		methodHandlers[rd.Method] = handler
+	}
+
+	// registering the same path again adds its methods to the existing route
+	for i, route := range m.routes {
+		if route.Path != fullPath {
+			continue
+		}
+
+		for method, methodHandler := range methodHandlers {
+			if _, ok := route.methodHandlers[method]; ok {
+				panic(fmt.Sprintf("swaggo: %s %s is already registered", method, fullPath))
+			}
+			m.routes[i].methodHandlers[method] = methodHandler
+		}
+
+		m.routes[i].RequestDetails = append(m.routes[i].RequestDetails, requestDetails...)
+		return
+	}
+
+	m.routes = append(m.routes, Route{Path: fullPath, Handler: handler, Prefix: m.prefix, Version: version, RequestDetails: requestDetails, methodHandlers: methodHandlers})
+	m.mux.Handle(fullPath, m.defaultMiddleware(len(m.routes)-1))
 
 }
 
-func (m *SwaggoMux) defaultMiddleware(handler http.Handler, requestDetails []RequestDetails) http.Handler {
+// ConfigureCors sets the default CORS policy for every route. Version and request detail configurations take precedence.
+func (m *SwaggoMux) ConfigureCors(corsConfiguration *CorsConfiguration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.corsConfiguration = corsConfiguration
+}
+
+// ConfigureVersion sets the CORS policy, authentication and deprecation of every operation of a version.
+// It panics when the version is deprecated without a DeprecatedAt date.
+func (m *SwaggoMux) ConfigureVersion(version string, versionConfiguration VersionConfiguration) {
+	if err := checkDeprecationDate(versionConfiguration.Deprecated, versionConfiguration.DeprecatedAt); err != nil {
+		panic(fmt.Sprintf("swaggo: version %s: %s", version, err))
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.versionConfigurations[version] = versionConfiguration
+}
+
+// ConfigureMaxBodySize limits the size in bytes of the JSON bodies buffered for validation. Larger bodies are answered with a 413
+// before the handler is called. Bodies that are not validated, such as uploads, are passed to the handler as they are.
+// A limit of zero or less accepts bodies of any size.
+func (m *SwaggoMux) ConfigureMaxBodySize(maxBodySize int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.maxBodySize = maxBodySize
+}
+
+// ConfigureNamingStrategy sets how query, path and header parameters without a name or json tag are named in the docs and
+// when binding. Body properties keep the names encoding/json decodes.
+func (m *SwaggoMux) ConfigureNamingStrategy(namingStrategy NamingStrategy) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.namingStrategy = namingStrategy
+}
+
+// ConfigureEmbeddedStructs sets whether embedded structs are promoted into their parent schema or composed with allOf.
+func (m *SwaggoMux) ConfigureEmbeddedStructs(embeddedStructMode EmbeddedStructMode) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.embeddedStructMode = embeddedStructMode
+}
+
+// ConfigureOpenApiVersion selects the generated document version. Nullable values use the nullable keyword in 3.0 and a type array in 3.1.
+func (m *SwaggoMux) ConfigureOpenApiVersion(openApiVersion OpenApiVersion) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.openApiVersion = openApiVersion
+}
+
+// ConfigureRequiredInference documents non-pointer fields without omitempty as required, matching what encoding/json always emits.
+// A required tag on the field still takes precedence.
+func (m *SwaggoMux) ConfigureRequiredInference(inferRequired bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inferRequired = inferRequired
+}
+
+// ConfigureSchemaNaming sets how component schemas are named for types without an alias or a SchemaName method.
+func (m *SwaggoMux) ConfigureSchemaNaming(schemaNaming SchemaNamingStrategy) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.schemaNaming = schemaNaming
+}
+
+// ConfigureGenericSchemaNaming sets how instantiated generic types, such as Page[User], are rendered in component schema names.
+func (m *SwaggoMux) ConfigureGenericSchemaNaming(genericNaming GenericSchemaNamingStrategy) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.genericNaming = genericNaming
+}
+
+// RegisterSchemaAlias names the component schema of the data's type, taking precedence over SchemaName and the naming strategy.
+func (m *SwaggoMux) RegisterSchemaAlias(data any, alias string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t := reflect.TypeOf(data)
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+
+	m.schemaAliases[t] = alias
+}
+
+// RegisterSchema adds the data's schema to components/schemas under the given name, even when no route references it.
+// A named struct is also referenced by that name wherever it is used, while unions and other types are registered as they are.
+// It fails for nil data and for data that is already registered under another name.
+func (m *SwaggoMux) RegisterSchema(name string, data any) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := registrationKey(data)
+
+	if key == nil {
+		return fmt.Errorf("schema %s has no data", name)
+	}
+
+	for _, registration := range m.registeredSchemas {
+		if registrationKey(registration.data) != key {
+			continue
+		}
+		if registration.name != name {
+			return fmt.Errorf("data of schema %s is already registered as %s", name, registration.name)
+		}
+		return nil
+	}
+
+	if t, ok := key.(reflect.Type); ok && t.Kind() == reflect.Struct && t.Name() != "" {
+		if alias, ok := m.schemaAliases[t]; ok && alias != name {
+			return fmt.Errorf("data of schema %s is already named %s", name, alias)
+		}
+		m.schemaAliases[t] = name
+	}
+
+	m.registeredSchemas = append(m.registeredSchemas, schemaRegistration{name: name, data: data})
+
+	return nil
+}
+
+// SchemaRef returns the $ref of the component schema generated for the data, so schemas built by hand can point at it.
+func (m *SwaggoMux) SchemaRef(data any) (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, registration := range m.registeredSchemas {
+		if registrationKey(registration.data) == registrationKey(data) {
+			return schemaRef(registration.name), nil
+		}
+	}
+
+	key := registrationKey(data)
+
+	if key == nil {
+		return "", fmt.Errorf("nil has no component schema")
+	}
+
+	t, ok := key.(reflect.Type)
+
+	if !ok {
+		return "", fmt.Errorf("union has no component schema. Register it with RegisterSchema")
+	}
+
+	_, custom := customTypeSchema(t)
+	_, union := typeUnion(t)
+
+	if t.Name() == "" || custom || (t.Kind() != reflect.Struct && !union) {
+		return "", fmt.Errorf("%s has no component schema. Register it with RegisterSchema", t.String())
+	}
+
+	name, err := m.newSchemaGenerator().componentName(t)
+
+	if err != nil {
+		return "", err
+	}
+
+	return schemaRef(name), nil
+}
+
+// registrationKey identifies registered data regardless of pointers. It is nil for nil data.
+func registrationKey(data any) any {
+	if union, ok := data.(Union); ok {
+		return union.key()
+	}
+
+	if data == nil {
+		return nil
+	}
+
+	t := reflect.TypeOf(data)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t
+}
+
+func (m *SwaggoMux) defaultMiddleware(routeIndex int) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 
+		m.mu.RLock()
+		version := m.routes[routeIndex].Version
+		requestDetails := m.routes[routeIndex].RequestDetails
+		handler := m.routes[routeIndex].methodHandlers[r.Method]
+		namingStrategy := m.namingStrategy
+		versionConfiguration := m.versionConfigurations[version]
+		maxBodySize := m.maxBodySize
+		m.mu.RUnlock()
+
+		r = r.WithContext(context.WithValue(r.Context(), namingStrategyContextKey{}, namingStrategy))
+
 		methods := ext.SliceMap(requestDetails, func(rd RequestDetails) string {
 			return rd.Method
 		})
 
-		if r.Method != http.MethodOptions && !ext.Contains(methods, r.Method) {
+		if isPreflightRequest(r) {
+			if corsConfiguration := m.getCorsConfiguration(version, requestDetails, r.Header.Get("Access-Control-Request-Method")); corsConfiguration != nil {
+				writePreflightHeaders(w, r, corsConfiguration, methods)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+
+		matchedRequestDetails := ext.Where(requestDetails, func(rd RequestDetails) bool {
+			return rd.Method == r.Method
+		})
+
+		if len(matchedRequestDetails) > 0 {
+			writeDeprecationHeaders(w, matchedRequestDetails[0], versionConfiguration)
+		}
+
+		if r.Header.Get("Origin") != "" {
+			if corsConfiguration := m.getCorsConfiguration(version, requestDetails, r.Method); corsConfiguration != nil {
+				writeCorsHeaders(w, r, corsConfiguration, matchedRequestDetails)
+			}
+		}
+
+		if len(matchedRequestDetails) == 0 {
+			w.Header().Set("Allow", allowHeader(methods))
+
+			if r.Method == http.MethodOptions {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
 			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		rd := matchedRequestDetails[0]
+		r = r.WithContext(context.WithValue(r.Context(), requestDetailsContextKey{}, rd))
+
+		principal, challenges, err := authenticate(r, rd.AuthenticationConfiguration)
+
+		if err != nil {
+			for _, challenge := range challenges {
+				w.Header().Add("WWW-Authenticate", challenge)
+			}
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if principal != nil {
+			if err := authorize(r, rd, *principal); err != nil {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			r = r.WithContext(context.WithValue(r.Context(), principalContextKey{}, *principal))
 		}
+
+		if err := validateRequest(r, rd, maxBodySize); err != nil {
+			writeHandlerError(w, err)
+			return
+		}
+
 		handler.ServeHTTP(w, r)
 	})
 }
 
+// allowHeader lists the registered methods, including OPTIONS which is always answered.
+func allowHeader(methods []string) string {
+	allowedMethods := ext.Distinct(methods)
+
+	if !ext.Contains(allowedMethods, http.MethodOptions) {
+		allowedMethods = append(allowedMethods, http.MethodOptions)
+	}
+
+	return strings.Join(allowedMethods, ", ")
+}
+
 func (m *SwaggoMux) HandleFunc(path string, handler func(http.ResponseWriter, *http.Request), version string, requestDetails ...RequestDetails) {
 	m.Handle(path, http.HandlerFunc(handler), version, requestDetails...)
 }
@@ -242,7 +558,7 @@ func (c *SwaggoMux) MapDoc(version string) (*SwagDoc, error) {
 	}
 
 	doc := &SwagDoc{
-		OpenAPIVersion: "3.0.2",
+		OpenAPIVersion: string(c.openApiVersion),
 		Info: Info{
 			Title:          c.swaggerInfo.Title,
 			Description:    c.swaggerInfo.Description,
@@ -307,7 +623,8 @@ func parseGOTypeToSwaggerType(kind reflect.Kind, t reflect.Type) string {
 	switch kind {
 	case reflect.String:
 		return "string"
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		return "integer"
 	case reflect.Float32, reflect.Float64:
 		return "number"
@@ -327,19 +644,6 @@ func parseGOTypeToSwaggerType(kind reflect.Kind, t reflect.Type) string {
 	}
 }
 
-func isArray(data any) bool {
-	t := reflect.TypeOf(data)
-	return t.Kind() == reflect.Slice || t.Kind() == reflect.Array
-}
-
-func isByteArray(field reflect.StructField) bool {
-	return field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.Uint8 // uint8 is byte in reflect package
-}
-
-func isTime(field reflect.StructField) bool {
-	return field.Type == reflect.TypeOf(time.Time{}) || field.Type == reflect.TypeOf(&time.Time{})
-}
-
 func autoType(kind reflect.Kind, value reflect.Value) any {
 	switch kind {
 	case reflect.String:
@@ -376,27 +680,46 @@ func (c *SwaggoMux) getRequestBodies(version string) (map[string]Body, error) {
 		return requestDetails.Requests
 	}), func(requestData RequestData) bool {
 		return requestData.Type == BodySource && requestData.Data != nil
-	}), func(requestData RequestData) string {
-		return reflect.TypeOf(requestData.Data).String()
+	}), func(requestData RequestData) any {
+		return dataKey(requestData.Data)
 	})
 
+	generator := c.newSchemaGenerator()
+	bodyKeys := make(map[string]any)
+
 	for _, reqBody := range distinctRequestBodies {
 
 		content := map[string]Content{}
 
-		splitSchemaName := strings.Split(reflect.TypeOf(reqBody.Data).String(), ".")
-		friendlyName := splitSchemaName[len(splitSchemaName)-1]
+		friendlyName, err := generator.dataName(reqBody.Data)
+
+		if err != nil {
+			return nil, err
+		}
+
+		// a pointer shares the name of its type, while any other data reusing a name would replace the earlier body
+		if key, ok := bodyKeys[friendlyName]; ok && key != registrationKey(reqBody.Data) {
+			return nil, fmt.Errorf("request body name %s is used by more than one type", friendlyName)
+		}
+
+		bodyKeys[friendlyName] = registrationKey(reqBody.Data)
 
 		if len(reqBody.ContentType) == 0 {
 			reqBody.ContentType = []string{"application/json"} // default to application/json if no type is given
 		}
 
+		schema, err := generator.dataSchema(reqBody.Data)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := checkExamples(reqBody.Examples); err != nil {
+			return nil, err
+		}
+
 		for _, contentType := range reqBody.ContentType {
-			content[contentType] = Content{
-				Schema: Schema{
-					Ref: fmt.Sprintf("#/components/schemas/%s", friendlyName),
-				},
-			}
+			content[contentType] = Content{Schema: schema, Examples: reqBody.Examples}
 		}
 
 		requestBodies[friendlyName] = Body{
@@ -411,7 +734,6 @@ func (c *SwaggoMux) getRequestBodies(version string) (map[string]Body, error) {
 }
 
 func (c *SwaggoMux) getSchemas(version string) (map[string]Schema, error) {
-	schemas := make(map[string]Schema)
 
 	distinctRequestTypes := ext.DistinctBy(ext.FlattenMap(ext.Where(c.routes, func(route Route) bool {
 		return (version == "" || route.Version == version)
@@ -419,24 +741,20 @@ func (c *SwaggoMux) getSchemas(version string) (map[string]Schema, error) {
 		return ext.FlattenMap(route.RequestDetails, func(requestDetails RequestDetails) []RequestData {
 			return requestDetails.Requests
 		})
-	}), func(reqBody RequestData) string {
-		if reqBody.Data == nil {
-			return ""
-		}
-		return reflect.TypeOf(reqBody.Data).String()
+	}), func(reqBody RequestData) any {
+		return dataKey(reqBody.Data)
 	})
 
-	distinctResponseTypes := ext.DistinctBy(ext.FlattenMap(ext.Where(c.routes, func(route Route) bool {
+	responses := ext.FlattenMap(ext.Where(c.routes, func(route Route) bool {
 		return (version == "" || route.Version == version)
 	}), func(route Route) []ResponseData {
 		return ext.FlattenMap(route.RequestDetails, func(requestDetails RequestDetails) []ResponseData {
 			return requestDetails.Responses
 		})
-	}), func(reqBody ResponseData) string {
-		if reqBody.Data == nil {
-			return ""
-		}
-		return reflect.TypeOf(reqBody.Data).String()
+	})
+
+	distinctResponseTypes := ext.DistinctBy(responses, func(reqBody ResponseData) any {
+		return dataKey(reqBody.Data)
 	})
 
 	distinctTypes := append(ext.SliceMap(distinctRequestTypes, func(req RequestData) any {
@@ -445,159 +763,49 @@ func (c *SwaggoMux) getSchemas(version string) (map[string]Schema, error) {
 		return res.Data
 	})...)
 
+	for _, res := range responses {
+		for _, header := range sortedKeys(res.Headers) {
+			distinctTypes = append(distinctTypes, res.Headers[header])
+		}
+	}
+
+	generator := c.newSchemaGenerator()
+
 	for _, data := range distinctTypes {
 
 		if data == nil {
 			continue
 		}
 
-		t, v, err := rawReflect(data)
-
-		if err != nil {
+		if _, err := generator.dataSchema(data); err != nil {
 			return nil, err
 		}
+	}
 
-		schema, err := mapChildPropertiesToSchema(t, v)
+	for _, registration := range c.registeredSchemas {
+		schema, err := generator.dataSchema(registration.data)
 
 		if err != nil {
 			return nil, err
 		}
 
-		var splitSchemaName = strings.Split(t.String(), ".")
-
-		schemas[splitSchemaName[len(splitSchemaName)-1]] = schema
-	}
-	return schemas, nil
-}
-
-func mapChildPropertiesToSchema(t reflect.Type, v reflect.Value) (Schema, error) {
-
-	properties := make(map[string]Property)
-	requiredProperties := make([]string, 0)
-
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-		value := v.Field(i)
-
-		var fName string
-
-		if field.Tag.Get("name") != "" {
-			fName = field.Tag.Get("name")
-		} else {
-			fName = field.Name
-		}
-
-		if field.Tag.Get("required") == "true" {
-			requiredProperties = append(requiredProperties, fName)
-		}
-
-		swagType := parseGOTypeToSwaggerType(value.Kind(), value.Type())
-
-		if swagType == "array" && isByteArray(field) {
-			properties[fName] = Property{
-				Type:        "string",
-				Format:      "binary",
-				Description: field.Tag.Get("description"),
-			}
+		if schema.Ref == schemaRef(registration.name) {
 			continue
 		}
 
-		if swagType == "array" {
-
-			arrayType := value.Type().Elem()
-
-			var childItemValue Schema
-
-			parsedType := parseGOTypeToSwaggerType(arrayType.Kind(), arrayType)
-
-			if parsedType == "array" {
-
-				if value.Len() == 0 {
-					value = reflect.MakeSlice(reflect.SliceOf(arrayType), 1, 1)
-				}
-
-				arrayValue := value.Index(0)
-
-				newT, newV, err := rawReflect(autoType(arrayValue.Kind(), arrayValue))
-
-				if err != nil {
-					return Schema{}, err
-				}
-
-				childItemValue, err = mapChildPropertiesToSchema(newT, newV)
-
-				if err != nil {
-					return Schema{}, err
-				}
-			} else if parsedType == "object" {
-				newT, newV, err := rawReflect(autoType(arrayType.Kind(), value))
-
-				if err != nil {
-					return Schema{}, err
-				}
-
-				childItemValue, err = mapChildPropertiesToSchema(newT, newV)
-
-				if err != nil {
-					return Schema{}, err
-				}
-			} else {
-				childItemValue = Schema{
-					Type: parsedType,
-				}
-			}
-
-			properties[fName] = Property{
-				Type:        "array",
-				Items:       &childItemValue,
-				Description: field.Tag.Get("description"),
-			}
-			continue
-		} else if swagType == "object" && isTime(field) {
-			properties[fName] = Property{
-				Type:        "string",
-				Format:      "date-time",
-				Description: field.Tag.Get("description"),
-			}
-			continue
-		} else if swagType == "object" {
-
-			newT, newV, err := rawReflect(autoType(value.Kind(), value))
-
-			if err != nil {
-				return Schema{}, err
-			}
-
-			childItemValue, err := mapChildPropertiesToSchema(newT, newV)
-
-			if err != nil {
-				return Schema{}, err
-			}
-
-			properties[fName] = Property{
-				Type:        "object",
-				Properties:  childItemValue.Properties,
-				Description: field.Tag.Get("description"),
-			}
-			continue
+		if _, ok := generator.schemas[registration.name]; ok {
+			return nil, fmt.Errorf("schema name %s is used by both a registered schema and a generated component", registration.name)
 		}
 
-		properties[fName] = Property{
-			Type:        swagType,
-			Description: field.Tag.Get("description"),
-			Example:     autoType(value.Kind(), value),
-		}
+		generator.schemas[registration.name] = schema
 	}
 
-	return Schema{
-		Type:       "object",
-		Properties: properties,
-		Required:   requiredProperties,
-	}, nil
+	return generator.schemas, nil
 }
 
 func (c *SwaggoMux) getPaths(version string) (map[string]map[string]Path, error) {
 	paths := make(map[string]map[string]Path)
+	generator := c.newSchemaGenerator()
 
 	for _, route := range ext.Where(c.routes, func(route Route) bool {
 		return !ext.Contains(IGNORED_TAGS, route.GetPathWithoutPrefixAndVersion()) && (version == "" || route.Version == version)
@@ -631,30 +839,41 @@ func (c *SwaggoMux) getPaths(version string) (map[string]map[string]Path, error)
 					field := t.Field(i)
 					value := v.Field(i)
 
-					var fName string
+					if !parameterInSource(field, qr.Type) {
+						continue
+					}
 
-					if field.Tag.Get("name") != "" {
-						fName = field.Tag.Get("name")
-					} else {
-						fName = field.Name
+					fName, ok := parameterName(field, c.namingStrategy)
+
+					if !ok {
+						continue
+					}
+
+					schema, err := generator.parameterSchema(field.Type, value)
+
+					if err != nil {
+						return nil, err
 					}
 
-					swagType := parseGOTypeToSwaggerType(value.Kind(), value.Type())
-					var optionalFormat string
-					if swagType == "array" && isByteArray(field) {
-						swagType = "string"
-						optionalFormat = "binary"
-					} else if swagType == "object" && isTime(field) {
-						swagType = "string"
-						optionalFormat = "date-time"
+					constraints, err := parseFieldConstraints(field)
+
+					if err != nil {
+						return nil, err
+					}
+
+					schema.Default, err = documentedDefault(field, value, qr.DataDefaults)
+
+					if err != nil {
+						return nil, err
 					}
 
 					parameters = append(parameters, Parameter{
 						Name:        fName,
 						In:          string(qr.Type),
 						Description: field.Tag.Get("description"),
-						Required:    field.Tag.Get("required") == "true",
-						Schema:      Schema{Type: swagType, Format: optionalFormat},
+						Required:    field.Tag.Get("required") == "true" || qr.Type == PathSource, // path parameters are always part of the matched path
+						Deprecated:  field.Tag.Get("deprecated") == "true",
+						Schema:      schemaWithoutRefSiblings(constraints.applyToSchema(schema)),
 					})
 				}
 			}
@@ -679,29 +898,18 @@ func (c *SwaggoMux) getPaths(version string) (map[string]map[string]Path, error)
 						br.ContentType = []string{"application/json"} // default to application/json if no type is given
 					}
 
-					splitTypeName := strings.Split(reflect.TypeOf(br.Data).String(), ".")
+					schema, err := generator.dataSchema(br.Data)
 
-					bodyIsArray := isArray(br.Data)
+					if err != nil {
+						return nil, err
+					}
 
-					if bodyIsArray {
-						for _, contentType := range br.ContentType {
-							body.Content[contentType] = Content{
-								Schema: Schema{
-									Type: "array",
-									Items: &Schema{
-										Ref: fmt.Sprintf("#/components/schemas/%s", splitTypeName[len(splitTypeName)-1]),
-									},
-								},
-							}
-						}
-					} else {
-						for _, contentType := range br.ContentType {
-							body.Content[contentType] = Content{
-								Schema: Schema{
-									Ref: fmt.Sprintf("#/components/schemas/%s", splitTypeName[len(splitTypeName)-1]),
-								},
-							}
-						}
+					if err := checkExamples(br.Examples); err != nil {
+						return nil, err
+					}
+
+					for _, contentType := range br.ContentType {
+						body.Content[contentType] = Content{Schema: schema, Examples: br.Examples}
 					}
 				}
 			}
@@ -710,41 +918,38 @@ func (c *SwaggoMux) getPaths(version string) (map[string]map[string]Path, error)
 
 			if len(rd.Responses) > 0 {
 				for _, res := range rd.Responses {
-					splitTypeName := strings.Split(reflect.TypeOf(res.Data).String(), ".")
-
 					content := map[string]Content{}
 
-					if len(res.ContentType) == 0 {
-						res.ContentType = []string{"application/json"} // default to application/json if no type is given
-					}
+					if res.Data != nil { // responses without data, such as a 204, have no content
+						if len(res.ContentType) == 0 {
+							res.ContentType = []string{"application/json"} // default to application/json if no type is given
+						}
 
-					responseIsArray := isArray(res.Data)
+						schema, err := generator.dataSchema(res.Data)
 
-					if responseIsArray {
-						for _, contentType := range res.ContentType {
-							content[contentType] = Content{
-								Schema: Schema{
-									Type: "array",
-									Items: &Schema{
-										Ref: fmt.Sprintf("#/components/schemas/%s", splitTypeName[len(splitTypeName)-1]),
-									},
-								},
-							}
+						if err != nil {
+							return nil, err
 						}
-					} else {
+
+						if err := checkExamples(res.Examples); err != nil {
+							return nil, err
+						}
+
 						for _, contentType := range res.ContentType {
-							content[contentType] = Content{
-								Schema: Schema{
-									Ref: fmt.Sprintf("#/components/schemas/%s", splitTypeName[len(splitTypeName)-1]),
-								},
-							}
+							content[contentType] = Content{Schema: schema, Examples: res.Examples}
 						}
 					}
 
 					headerMap := make(map[string]Header)
 
 					for header, value := range res.Headers {
-						headerMap[header] = Header{Schema: Schema{Type: parseGOTypeToSwaggerType(reflect.TypeOf(value).Kind(), reflect.ValueOf(value).Type())}}
+						schema, err := generator.dataSchema(value)
+
+						if err != nil {
+							return nil, err
+						}
+
+						headerMap[header] = Header{Schema: schema}
 					}
 
 					responses[fmt.Sprintf("%d", res.Code)] = Response{
@@ -798,9 +1003,18 @@ func (c *SwaggoMux) getPaths(version string) (map[string]map[string]Path, error)
 					if rd.AuthenticationConfiguration.Oauth2Auth.Name == "" {
 						rd.AuthenticationConfiguration.Oauth2Auth.Name = "oauth2"
 					}
-					securityMemberships = append(securityMemberships, map[string][]string{
-						rd.AuthenticationConfiguration.Oauth2Auth.Name: rd.OauthScopes,
-					})
+					if rd.OauthScopeMatch == AnyScope && len(rd.OauthScopes) > 1 {
+						// any-of scopes are expressed as alternative security requirements
+						for _, scope := range rd.OauthScopes {
+							securityMemberships = append(securityMemberships, map[string][]string{
+								rd.AuthenticationConfiguration.Oauth2Auth.Name: {scope},
+							})
+						}
+					} else {
+						securityMemberships = append(securityMemberships, map[string][]string{
+							rd.AuthenticationConfiguration.Oauth2Auth.Name: rd.OauthScopes,
+						})
+					}
 				}
 			}
 
@@ -813,6 +1027,7 @@ func (c *SwaggoMux) getPaths(version string) (map[string]map[string]Path, error)
 				RequestBody: body,
 				Responses:   responses,
 				Security:    securityMemberships,
+				Deprecated:  isDeprecated(rd, c.versionConfigurations[route.Version]),
 			}
 		}
 
@@ -821,6 +1036,22 @@ func (c *SwaggoMux) getPaths(version string) (map[string]map[string]Path, error)
 	return paths, nil
 }
 
+// parameterInSource reports whether a field belongs to the given source. Fields without an in tag belong to every source.
+func parameterInSource(field reflect.StructField, source RequestDataSource) bool {
+	in := field.Tag.Get("in")
+	return in == "" || RequestDataSource(in) == source
+}
+
+// checkExamples rejects examples that set both an inline value and an external one, which OpenAPI treats as mutually exclusive.
+func checkExamples(examples map[string]Example) error {
+	for _, name := range sortedKeys(examples) {
+		if examples[name].Value != nil && examples[name].ExternalValue != "" {
+			return fmt.Errorf("example %s cannot have both a value and an externalValue", name)
+		}
+	}
+	return nil
+}
+
 func (c *SwaggoMux) getSecuritySchemas() map[string]SecurityScheme {
 	allAuthenticationConfigurations := ext.Where(ext.SliceMap(ext.FlattenMap(c.routes, func(route Route) []RequestDetails {
 		return route.RequestDetails
@@ -0,0 +1,506 @@
+package swaggo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Pieeer1/Auto-SwagGo/internal/ext"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+var compiledPatterns sync.Map
+
+// fieldConstraints holds the validation struct tags of a field. They are emitted as OpenAPI keywords and enforced at runtime.
+type fieldConstraints struct {
+	Minimum   *float64
+	Maximum   *float64
+	MinLength *int
+	MaxLength *int
+	Pattern   string
+	MinItems  *int
+	MaxItems  *int
+	Enum      []string
+	Format    string
+	IsArray   bool
+	ItemKind  reflect.Kind
+}
+
+// checkConstraintTags parses the constraint tags of every field reachable from the data, so an invalid tag fails the
+// registration of its route instead of being skipped on every request.
+func checkConstraintTags(data any) error {
+	seen := map[reflect.Type]bool{}
+
+	if union, ok := data.(Union); ok {
+		return checkVariantConstraintTags(union, seen)
+	}
+
+	return checkTypeConstraintTags(reflect.TypeOf(data), seen)
+}
+
+func checkVariantConstraintTags(union Union, seen map[reflect.Type]bool) error {
+	for _, variant := range union.variants {
+		if err := checkTypeConstraintTags(reflect.TypeOf(variant), seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkTypeConstraintTags(t reflect.Type, seen map[reflect.Type]bool) error {
+	if t == nil || seen[t] {
+		return nil
+	}
+	seen[t] = true
+
+	if union, ok := typeUnion(t); ok {
+		return checkVariantConstraintTags(union, seen)
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return checkTypeConstraintTags(t.Elem(), seen)
+	case reflect.Struct:
+		if _, ok := customTypeSchema(t); ok {
+			return nil
+		}
+
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+
+			if !field.IsExported() && !field.Anonymous {
+				continue
+			}
+
+			if _, err := parseFieldConstraints(field); err != nil {
+				return err
+			}
+
+			if err := checkTypeConstraintTags(field.Type, seen); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func parseFieldConstraints(field reflect.StructField) (fieldConstraints, error) {
+	constraints := fieldConstraints{
+		Pattern: field.Tag.Get("pattern"),
+		Format:  field.Tag.Get("format"),
+	}
+
+	t := field.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	constraints.ItemKind = t.Kind()
+	if (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && t.Elem().Kind() != reflect.Uint8 {
+		constraints.IsArray = true
+		constraints.ItemKind = t.Elem().Kind()
+		if constraints.ItemKind == reflect.Ptr {
+			constraints.ItemKind = t.Elem().Elem().Kind()
+		}
+	}
+
+	var err error
+
+	if constraints.Minimum, err = parseFloatTag(field, "minimum"); err != nil {
+		return constraints, err
+	}
+	if constraints.Maximum, err = parseFloatTag(field, "maximum"); err != nil {
+		return constraints, err
+	}
+	if constraints.MinLength, err = parseIntTag(field, "minLength"); err != nil {
+		return constraints, err
+	}
+	if constraints.MaxLength, err = parseIntTag(field, "maxLength"); err != nil {
+		return constraints, err
+	}
+	if constraints.MinItems, err = parseIntTag(field, "minItems"); err != nil {
+		return constraints, err
+	}
+	if constraints.MaxItems, err = parseIntTag(field, "maxItems"); err != nil {
+		return constraints, err
+	}
+
+	if enum := field.Tag.Get("enum"); enum != "" {
+		constraints.Enum = ext.SliceMap(strings.Split(enum, ","), strings.TrimSpace)
+		for _, enumValue := range constraints.Enum {
+			if _, err := parseEnumValue(enumValue, constraints.ItemKind); err != nil {
+				return constraints, fmt.Errorf("invalid enum value %q on field %s: %s", enumValue, field.Name, err.Error())
+			}
+		}
+	}
+
+	if len(constraints.Enum) == 0 {
+		itemType := t
+		if constraints.IsArray {
+			itemType = t.Elem()
+			if itemType.Kind() == reflect.Ptr {
+				itemType = itemType.Elem()
+			}
+		}
+		constraints.Enum = typeEnumConstraints(itemType).Enum
+	}
+
+	if constraints.Pattern != "" {
+		if _, err := compilePattern(constraints.Pattern); err != nil {
+			return constraints, fmt.Errorf("invalid pattern on field %s: %s", field.Name, err.Error())
+		}
+	}
+
+	return constraints, nil
+}
+
+// EnumProvider lets a named type, such as a set of string constants, document and enforce its allowed values.
+type EnumProvider interface {
+	EnumValues() []any
+}
+
+var enumProviderType = reflect.TypeOf((*EnumProvider)(nil)).Elem()
+
+// typeEnumValues returns the allowed values of a type implementing EnumProvider, converted to their underlying kind.
+func typeEnumValues(t reflect.Type) []any {
+	var enumValues []any
+
+	switch {
+	case t.Kind() != reflect.Interface && t.Kind() != reflect.Ptr && t.Implements(enumProviderType):
+		enumValues = reflect.Zero(t).Interface().(EnumProvider).EnumValues()
+	case t.Kind() != reflect.Interface && reflect.PointerTo(t).Implements(enumProviderType):
+		enumValues = reflect.New(t).Interface().(EnumProvider).EnumValues()
+	default:
+		return nil
+	}
+
+	return ext.SliceMap(enumValues, func(enumValue any) any {
+		v := reflect.ValueOf(enumValue)
+		switch v.Kind() {
+		case reflect.String:
+			return v.String()
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return v.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return int64(v.Uint())
+		case reflect.Float32, reflect.Float64:
+			return v.Float()
+		case reflect.Bool:
+			return v.Bool()
+		default:
+			return enumValue
+		}
+	})
+}
+
+// typeEnumConstraints enforces the values of a type implementing EnumProvider wherever the type is used, including map values,
+// slice items and whole request bodies.
+func typeEnumConstraints(t reflect.Type) fieldConstraints {
+	return fieldConstraints{
+		Enum: ext.SliceMap(typeEnumValues(t), func(enumValue any) string {
+			return fmt.Sprint(enumValue)
+		}),
+		ItemKind: t.Kind(),
+	}
+}
+
+func parseFloatTag(field reflect.StructField, tag string) (*float64, error) {
+	if field.Tag.Get(tag) == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseFloat(field.Tag.Get(tag), 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s tag on field %s: %s", tag, field.Name, err.Error())
+	}
+	return &parsed, nil
+}
+
+func parseIntTag(field reflect.StructField, tag string) (*int, error) {
+	if field.Tag.Get(tag) == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.Atoi(field.Tag.Get(tag))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s tag on field %s: %s", tag, field.Name, err.Error())
+	}
+	return &parsed, nil
+}
+
+func parseEnumValue(value string, kind reflect.Kind) (any, error) {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.ParseInt(value, 10, 64)
+	case reflect.Float32, reflect.Float64:
+		return strconv.ParseFloat(value, 64)
+	case reflect.Bool:
+		return strconv.ParseBool(value)
+	default:
+		return value, nil
+	}
+}
+
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if compiled, ok := compiledPatterns.Load(pattern); ok {
+		return compiled.(*regexp.Regexp), nil
+	}
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	compiledPatterns.Store(pattern, compiled)
+	return compiled, nil
+}
+
+func (c fieldConstraints) enumValues() []any {
+	if len(c.Enum) == 0 {
+		return nil
+	}
+	return ext.SliceMap(c.Enum, func(enumValue string) any {
+		parsed, _ := parseEnumValue(enumValue, c.ItemKind)
+		return parsed
+	})
+}
+
+func (c fieldConstraints) applyToProperty(property Property) Property {
+	if c.Minimum != nil {
+		property.Minimum = c.Minimum
+	}
+	if c.Maximum != nil {
+		property.Maximum = c.Maximum
+	}
+	if c.MinLength != nil {
+		property.MinLength = c.MinLength
+	}
+	if c.MaxLength != nil {
+		property.MaxLength = c.MaxLength
+	}
+	if c.Pattern != "" {
+		property.Pattern = c.Pattern
+	}
+	if c.MinItems != nil {
+		property.MinItems = c.MinItems
+	}
+	if c.MaxItems != nil {
+		property.MaxItems = c.MaxItems
+	}
+
+	if c.Format != "" {
+		property.Format = c.Format
+	}
+
+	if len(c.Enum) == 0 {
+		return property
+	}
+
+	if c.IsArray && property.Items != nil {
+		items := *property.Items
+		items.Enum = c.enumValues()
+		property.Items = &items
+	} else {
+		property.Enum = c.enumValues()
+	}
+
+	return property
+}
+
+func (c fieldConstraints) applyToSchema(schema Schema) Schema {
+	if c.Minimum != nil {
+		schema.Minimum = c.Minimum
+	}
+	if c.Maximum != nil {
+		schema.Maximum = c.Maximum
+	}
+	if c.MinLength != nil {
+		schema.MinLength = c.MinLength
+	}
+	if c.MaxLength != nil {
+		schema.MaxLength = c.MaxLength
+	}
+	if c.Pattern != "" {
+		schema.Pattern = c.Pattern
+	}
+	if c.MinItems != nil {
+		schema.MinItems = c.MinItems
+	}
+	if c.MaxItems != nil {
+		schema.MaxItems = c.MaxItems
+	}
+
+	if c.Format != "" {
+		schema.Format = c.Format
+	}
+
+	if len(c.Enum) == 0 {
+		return schema
+	}
+
+	if c.IsArray && schema.Items != nil {
+		items := *schema.Items
+		items.Enum = c.enumValues()
+		schema.Items = &items
+	} else {
+		schema.Enum = c.enumValues()
+	}
+
+	return schema
+}
+
+// check validates a decoded value. Numbers are expected as float64 or json.Number and arrays as []any.
+func (c fieldConstraints) check(path string, value any) ValidationErrors {
+	validationErrors := make(ValidationErrors, 0)
+
+	if number, ok := value.(json.Number); ok {
+		parsed, err := number.Float64()
+		if err != nil {
+			return validationErrors
+		}
+		value = parsed
+	}
+
+	switch typedValue := value.(type) {
+	case float64:
+		if c.Minimum != nil && typedValue < *c.Minimum {
+			validationErrors = append(validationErrors, ValidationError{Field: path, Message: fmt.Sprintf("must be greater than or equal to %v", *c.Minimum)})
+		}
+		if c.Maximum != nil && typedValue > *c.Maximum {
+			validationErrors = append(validationErrors, ValidationError{Field: path, Message: fmt.Sprintf("must be less than or equal to %v", *c.Maximum)})
+		}
+		if !c.IsArray && !c.matchesEnum(typedValue) {
+			validationErrors = append(validationErrors, ValidationError{Field: path, Message: fmt.Sprintf("must be one of %s", strings.Join(c.Enum, ", "))})
+		}
+	case string:
+		length := utf8.RuneCountInString(typedValue)
+		if c.MinLength != nil && length < *c.MinLength {
+			validationErrors = append(validationErrors, ValidationError{Field: path, Message: fmt.Sprintf("must be at least %d characters", *c.MinLength)})
+		}
+		if c.MaxLength != nil && length > *c.MaxLength {
+			validationErrors = append(validationErrors, ValidationError{Field: path, Message: fmt.Sprintf("must be at most %d characters", *c.MaxLength)})
+		}
+		if c.Pattern != "" {
+			if pattern, err := compilePattern(c.Pattern); err == nil && !pattern.MatchString(typedValue) {
+				validationErrors = append(validationErrors, ValidationError{Field: path, Message: fmt.Sprintf("must match pattern %s", c.Pattern)})
+			}
+		}
+		if !isValidFormat(c.Format, typedValue) {
+			validationErrors = append(validationErrors, ValidationError{Field: path, Message: fmt.Sprintf("must be a valid %s", c.Format)})
+		}
+		if !c.IsArray && !c.matchesEnum(typedValue) {
+			validationErrors = append(validationErrors, ValidationError{Field: path, Message: fmt.Sprintf("must be one of %s", strings.Join(c.Enum, ", "))})
+		}
+	case bool:
+		if !c.IsArray && !c.matchesEnum(typedValue) {
+			validationErrors = append(validationErrors, ValidationError{Field: path, Message: fmt.Sprintf("must be one of %s", strings.Join(c.Enum, ", "))})
+		}
+	case []any:
+		if c.MinItems != nil && len(typedValue) < *c.MinItems {
+			validationErrors = append(validationErrors, ValidationError{Field: path, Message: fmt.Sprintf("must contain at least %d items", *c.MinItems)})
+		}
+		if c.MaxItems != nil && len(typedValue) > *c.MaxItems {
+			validationErrors = append(validationErrors, ValidationError{Field: path, Message: fmt.Sprintf("must contain at most %d items", *c.MaxItems)})
+		}
+		if c.IsArray {
+			for i, item := range typedValue {
+				if number, ok := item.(json.Number); ok {
+					item, _ = number.Float64()
+				}
+				if !c.matchesEnum(item) {
+					validationErrors = append(validationErrors, ValidationError{Field: fmt.Sprintf("%s[%d]", path, i), Message: fmt.Sprintf("must be one of %s", strings.Join(c.Enum, ", "))})
+				}
+			}
+		}
+	}
+
+	return validationErrors
+}
+
+func (c fieldConstraints) matchesEnum(value any) bool {
+	if len(c.Enum) == 0 || value == nil {
+		return true
+	}
+	for _, enumValue := range c.enumValues() {
+		switch typedEnumValue := enumValue.(type) {
+		case int64:
+			if number, ok := value.(float64); ok && float64(typedEnumValue) == number {
+				return true
+			}
+		default:
+			if typedEnumValue == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isValidFormat(format, value string) bool {
+	switch format {
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, value)
+		return err == nil
+	case "date":
+		_, err := time.Parse(time.DateOnly, value)
+		return err == nil
+	case "email":
+		address, err := mail.ParseAddress(value)
+		return err == nil && address.Address == value
+	case "uuid":
+		return uuidPattern.MatchString(value)
+	case "uri":
+		parsed, err := url.ParseRequestURI(value)
+		return err == nil && parsed.Scheme != ""
+	case "ipv4":
+		ip := net.ParseIP(value)
+		return ip != nil && ip.To4() != nil
+	case "ipv6":
+		ip := net.ParseIP(value)
+		return ip != nil && ip.To4() == nil
+	default:
+		return true
+	}
+}
+
+// constraintValue converts a bound Go value into the shape expected by check.
+func constraintValue(v reflect.Value) any {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return nil
+		}
+		items := make([]any, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			items[i] = constraintValue(v.Index(i))
+		}
+		return items
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,108 @@
+package swaggo
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Pieeer1/Auto-SwagGo/internal/ext"
+)
+
+func isPreflightRequest(r *http.Request) bool {
+	return r.Method == http.MethodOptions && r.Header.Get("Origin") != "" && r.Header.Get("Access-Control-Request-Method") != ""
+}
+
+// getCorsConfiguration resolves the policy for a method, preferring the request details, then the version, then the mux.
+func (m *SwaggoMux) getCorsConfiguration(version string, requestDetails []RequestDetails, method string) *CorsConfiguration {
+	for _, rd := range requestDetails {
+		if rd.Method == method && rd.CorsConfiguration != nil {
+			return rd.CorsConfiguration
+		}
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if versionConfiguration, ok := m.versionConfigurations[version]; ok && versionConfiguration.CorsConfiguration != nil {
+		return versionConfiguration.CorsConfiguration
+	}
+
+	return m.corsConfiguration
+}
+
+func (c *CorsConfiguration) allowsOrigin(origin string) bool {
+	return ext.Contains(c.AllowedOrigins, "*") || len(ext.Where(c.AllowedOrigins, func(allowedOrigin string) bool {
+		return strings.EqualFold(allowedOrigin, origin)
+	})) > 0
+}
+
+func writeAllowOriginHeaders(w http.ResponseWriter, origin string, corsConfiguration *CorsConfiguration) {
+	w.Header().Add("Vary", "Origin")
+
+	if ext.Contains(corsConfiguration.AllowedOrigins, "*") && !corsConfiguration.AllowCredentials {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+	} else {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+	}
+
+	if corsConfiguration.AllowCredentials {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+// writePreflightHeaders answers a preflight with the methods registered on the route.
+func writePreflightHeaders(w http.ResponseWriter, r *http.Request, corsConfiguration *CorsConfiguration, methods []string) {
+	origin := r.Header.Get("Origin")
+	requestedMethod := r.Header.Get("Access-Control-Request-Method")
+
+	w.Header().Set("Allow", allowHeader(methods))
+
+	if !corsConfiguration.allowsOrigin(origin) || !ext.Contains(methods, requestedMethod) {
+		return
+	}
+
+	writeAllowOriginHeaders(w, origin, corsConfiguration)
+	w.Header().Set("Access-Control-Allow-Methods", strings.Join(ext.Distinct(methods), ", "))
+
+	if ext.Contains(corsConfiguration.AllowedHeaders, "*") {
+		if requestedHeaders := r.Header.Get("Access-Control-Request-Headers"); requestedHeaders != "" {
+			w.Header().Add("Vary", "Access-Control-Request-Headers")
+			w.Header().Set("Access-Control-Allow-Headers", requestedHeaders)
+		}
+	} else if len(corsConfiguration.AllowedHeaders) > 0 {
+		w.Header().Set("Access-Control-Allow-Headers", strings.Join(corsConfiguration.AllowedHeaders, ", "))
+	}
+
+	if corsConfiguration.MaxAge > 0 {
+		w.Header().Set("Access-Control-Max-Age", fmt.Sprintf("%d", corsConfiguration.MaxAge))
+	}
+}
+
+// writeCorsHeaders decorates an actual cross origin request. Headers documented on the responses are always exposed.
+func writeCorsHeaders(w http.ResponseWriter, r *http.Request, corsConfiguration *CorsConfiguration, requestDetails []RequestDetails) {
+	origin := r.Header.Get("Origin")
+
+	if !corsConfiguration.allowsOrigin(origin) {
+		return
+	}
+
+	writeAllowOriginHeaders(w, origin, corsConfiguration)
+
+	exposedHeaders := append([]string{}, corsConfiguration.ExposedHeaders...)
+
+	for _, rd := range requestDetails {
+		for _, response := range rd.Responses {
+			exposedHeaders = append(exposedHeaders, sortedKeys(response.Headers)...)
+		}
+	}
+
+	for _, header := range []string{"Deprecation", "Sunset"} {
+		if w.Header().Get(header) != "" {
+			exposedHeaders = append(exposedHeaders, header)
+		}
+	}
+
+	if len(exposedHeaders) > 0 {
+		w.Header().Set("Access-Control-Expose-Headers", strings.Join(ext.Distinct(exposedHeaders), ", "))
+	}
+}
@@ -0,0 +1,144 @@
+package swaggo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// fieldDefault parses the default tag of a field into a value of the field's type, using the parameter conversion rules.
+// Slices take comma separated values.
+func fieldDefault(field reflect.StructField) (reflect.Value, bool, error) {
+	tag, ok := field.Tag.Lookup("default")
+
+	if !ok {
+		return reflect.Value{}, false, nil
+	}
+
+	values := []string{tag}
+
+	if isSliceParameter(field.Type) {
+		values = strings.Split(tag, ",")
+		for i := range values {
+			values[i] = strings.TrimSpace(values[i])
+		}
+	}
+
+	v := reflect.New(field.Type).Elem()
+
+	if err := setParameterValue(v, values); err != nil {
+		return reflect.Value{}, false, fmt.Errorf("invalid default on field %s: %s", field.Name, err.Error())
+	}
+
+	return v, true, nil
+}
+
+// documentedDefault returns the default of a field for the docs. The default tag wins over a non-zero value of
+// registered data that opted into DataDefaults.
+func documentedDefault(field reflect.StructField, value reflect.Value, dataDefaults bool) (any, error) {
+	tagDefault, ok, err := fieldDefault(field)
+
+	switch {
+	case err != nil:
+		return nil, err
+	case ok:
+		return tagDefault.Interface(), nil
+	case dataDefaults && value.IsValid() && !value.IsZero():
+		return value.Interface(), nil
+	default:
+		return nil, nil
+	}
+}
+
+// applyDefaults fills the fields of a struct, including nested structs, from their default tags before a body is decoded into it.
+// encoding/json keeps the values of absent properties, so only what the client sent replaces them.
+func applyDefaults(v reflect.Value) {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if value, ok, err := fieldDefault(field); ok && err == nil {
+			v.Field(i).Set(value)
+			continue
+		}
+
+		if _, custom := customTypeSchema(field.Type); field.Type.Kind() == reflect.Struct && !custom {
+			applyDefaults(v.Field(i))
+		}
+	}
+}
+
+type requestDetailsContextKey struct{}
+
+// registeredDefaults returns a copy of the registered data of the source when it opted into DataDefaults and has the given type.
+func registeredDefaults(ctx context.Context, source RequestDataSource, t reflect.Type) (reflect.Value, bool) {
+	requestDetails, ok := ctx.Value(requestDetailsContextKey{}).(RequestDetails)
+
+	if !ok {
+		return reflect.Value{}, false
+	}
+
+	for _, requestData := range requestDetails.Requests {
+		if requestData.Type != source || !requestData.DataDefaults || requestData.Data == nil {
+			continue
+		}
+
+		if _, v, err := rawReflect(requestData.Data); err == nil && v.Type() == t {
+			return cloneValue(v), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// cloneValue deep copies registered data so decoding into the copy cannot modify the registered instance.
+func cloneValue(v reflect.Value) reflect.Value {
+	clone := reflect.New(v.Type()).Elem()
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			clone.Set(reflect.New(v.Type().Elem()))
+			clone.Elem().Set(cloneValue(v.Elem()))
+		}
+	case reflect.Slice:
+		if !v.IsNil() {
+			clone.Set(reflect.MakeSlice(v.Type(), v.Len(), v.Len()))
+			for i := 0; i < v.Len(); i++ {
+				clone.Index(i).Set(cloneValue(v.Index(i)))
+			}
+		}
+	case reflect.Map:
+		if !v.IsNil() {
+			clone.Set(reflect.MakeMapWithSize(v.Type(), v.Len()))
+			for _, key := range v.MapKeys() {
+				clone.SetMapIndex(key, cloneValue(v.MapIndex(key)))
+			}
+		}
+	case reflect.Struct:
+		clone.Set(v) // copies unexported fields, which cannot be set one by one
+		for i := 0; i < v.NumField(); i++ {
+			if clone.Field(i).CanSet() {
+				clone.Field(i).Set(cloneValue(v.Field(i)))
+			}
+		}
+	default:
+		clone.Set(v)
+	}
+
+	return clone
+}
+
+// withDefaults prepares the target of a bind from the registered data defaults of the request.
+func withDefaults(r *http.Request, source RequestDataSource, v reflect.Value) {
+	if defaults, ok := registeredDefaults(r.Context(), source, v.Type()); ok {
+		v.Set(defaults)
+	}
+}
@@ -0,0 +1,45 @@
+package swaggo
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// isDeprecated reports whether an operation is deprecated by itself or by its version, through the flag or a date.
+func isDeprecated(requestDetails RequestDetails, versionConfiguration VersionConfiguration) bool {
+	return requestDetails.Deprecated || !requestDetails.DeprecatedAt.IsZero() ||
+		versionConfiguration.Deprecated || !versionConfiguration.DeprecatedAt.IsZero()
+}
+
+// writeDeprecationHeaders announces a deprecated operation or version with the RFC 9745 Deprecation header, which carries
+// the date as "@" and Unix seconds, and the Sunset header. The operation's dates win over the version's.
+func writeDeprecationHeaders(w http.ResponseWriter, requestDetails RequestDetails, versionConfiguration VersionConfiguration) {
+	if deprecatedAt := firstDate(requestDetails.DeprecatedAt, versionConfiguration.DeprecatedAt); !deprecatedAt.IsZero() {
+		w.Header().Set("Deprecation", fmt.Sprintf("@%d", deprecatedAt.Unix()))
+	}
+
+	if sunset := firstDate(requestDetails.Sunset, versionConfiguration.Sunset); !sunset.IsZero() {
+		w.Header().Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+	}
+}
+
+// checkDeprecationDate requires a date along with the Deprecated flag, since RFC 9745 only allows the Deprecation header
+// with a date.
+func checkDeprecationDate(deprecated bool, deprecatedAt time.Time) error {
+	if deprecated && deprecatedAt.IsZero() {
+		return fmt.Errorf("Deprecated requires a DeprecatedAt date to send the Deprecation header")
+	}
+
+	return nil
+}
+
+func firstDate(dates ...time.Time) time.Time {
+	for _, date := range dates {
+		if !date.IsZero() {
+			return date
+		}
+	}
+
+	return time.Time{}
+}
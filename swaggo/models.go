@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 )
 
 type RequestDataSource string
@@ -21,6 +22,7 @@ type Route struct {
 	Prefix         string
 	Version        string
 	RequestDetails []RequestDetails
+	methodHandlers map[string]http.Handler
 }
 
 func (r *Route) GetPathWithoutPrefixAndVersion() string {
@@ -49,8 +51,28 @@ type RequestDetails struct {
 	Description                 string
 	AuthenticationConfiguration *AuthenticationConfiguration
 	OauthScopes                 []string
+	OauthScopeMatch             ScopeMatch
+	CorsConfiguration           *CorsConfiguration
 	Requests                    []RequestData
 	Responses                   []ResponseData
+	Deprecated                  bool      // requires DeprecatedAt, registering the operation panics without it
+	DeprecatedAt                time.Time // deprecates the operation and is sent as the Deprecation header when set
+	Sunset                      time.Time // sent as the Sunset header when set
+}
+
+type CorsConfiguration struct {
+	AllowedOrigins   []string // "*" allows any origin
+	AllowedHeaders   []string // "*" allows any requested header
+	ExposedHeaders   []string // exposed in addition to the headers documented on the responses
+	AllowCredentials bool
+	MaxAge           int // seconds a preflight response may be cached
+}
+
+type VersionConfiguration struct {
+	CorsConfiguration *CorsConfiguration
+	Deprecated        bool      // deprecates every operation of the version and requires DeprecatedAt
+	DeprecatedAt      time.Time // deprecates every operation of the version and is sent as the Deprecation header when set
+	Sunset            time.Time // sent as the Sunset header when set
 }
 
 type AuthenticationConfiguration struct {
@@ -59,30 +81,37 @@ type AuthenticationConfiguration struct {
 	ApiKeyAuth *ApiKeyAuth
 	OpenIdAuth *OpenIdAuth
 	Oauth2Auth *Oauth2Auth
+	Authorize  AuthorizationCallback
 }
 
 type BasicAuth struct {
-	Name string
+	Name   string
+	Realm  string
+	Verify AuthVerifier
 }
 
 type BearerAuth struct {
-	Name string
+	Name   string
+	Verify AuthVerifier
 }
 
 type ApiKeyAuth struct {
-	In   string
-	Name string
+	In     string
+	Name   string
+	Verify AuthVerifier
 }
 
 type OpenIdAuth struct {
 	Name             string
 	OpenIdConnectUrl string
+	Verify           AuthVerifier
 }
 
 type Oauth2Auth struct {
 	Name        string
 	Description string
 	Flows       Oauth2Flows
+	Verify      AuthVerifier
 }
 
 type Oauth2Flows struct {
@@ -100,15 +129,18 @@ type Oauth2Flow struct {
 }
 
 type RequestData struct {
-	Type        RequestDataSource
-	Description string
-	Required    bool
-	ContentType []string
-	Data        any
+	Type         RequestDataSource
+	Description  string
+	Required     bool
+	ContentType  []string
+	Data         any
+	Examples     map[string]Example // body only, keyed by the name shown in the dropdown
+	DataDefaults bool               // document and apply the non-zero values of Data as defaults
 }
 type ResponseData struct {
 	Code        int
 	Data        any
 	ContentType []string
 	Headers     map[string]any
+	Examples    map[string]Example
 }
@@ -0,0 +1,411 @@
+package swaggo
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/Pieeer1/Auto-SwagGo/internal/ext"
+)
+
+// NamingStrategy names query, path and header parameters that have no name or json tag.
+type NamingStrategy func(fieldName string) string
+
+// FieldNameStrategy keeps the Go field name, matching encoding/json. This is the default.
+func FieldNameStrategy(fieldName string) string {
+	return fieldName
+}
+
+// CamelCaseStrategy lower cases the leading word, so UserID becomes userId.
+func CamelCaseStrategy(fieldName string) string {
+	words := splitFieldName(fieldName)
+
+	for i, word := range words {
+		if i == 0 {
+			words[i] = strings.ToLower(word)
+		} else {
+			words[i] = strings.ToUpper(word[:1]) + strings.ToLower(word[1:])
+		}
+	}
+
+	return strings.Join(words, "")
+}
+
+// SnakeCaseStrategy lower cases and underscores every word, so UserID becomes user_id.
+func SnakeCaseStrategy(fieldName string) string {
+	words := splitFieldName(fieldName)
+
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+
+	return strings.Join(words, "_")
+}
+
+// splitFieldName splits a Go identifier into words, keeping initialisms such as HTTP or ID together.
+func splitFieldName(fieldName string) []string {
+	runes := []rune(fieldName)
+	words := make([]string, 0)
+	start := 0
+
+	for i := 1; i < len(runes); i++ {
+		previous, current := runes[i-1], runes[i]
+		nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+
+		if runes[i] == '_' {
+			if start < i {
+				words = append(words, string(runes[start:i]))
+			}
+			start = i + 1
+			continue
+		}
+
+		if unicode.IsUpper(current) && (unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextIsLower)) {
+			if start < i {
+				words = append(words, string(runes[start:i]))
+			}
+			start = i
+		}
+	}
+
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+
+	return words
+}
+
+func jsonTagName(field reflect.StructField) string {
+	tagName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	return tagName
+}
+
+type jsonField struct {
+	name   string
+	field  reflect.StructField // Index is the path from the outer struct, through any embedded structs
+	tagged bool
+}
+
+// jsonFields returns the fields encoding/json would encode and decode, promoting untagged embedded structs.
+// A name declared at several depths follows the encoding/json dominance rules: the shallowest field wins, a tagged field wins over
+// untagged ones at the same depth, and any other tie hides the name entirely. Both the docs and the validator use this list.
+func jsonFields(t reflect.Type) []jsonField {
+	type embedded struct {
+		t     reflect.Type
+		index []int
+	}
+
+	candidates := make(map[string][]jsonField)
+	names := make([]string, 0)
+	visited := make(map[reflect.Type]bool)
+
+	for level := []embedded{{t: t}}; len(level) > 0; {
+		next := make([]embedded, 0)
+		count := make(map[reflect.Type]int)
+
+		for _, e := range level {
+			count[e.t]++
+		}
+
+		for _, e := range level {
+			if visited[e.t] {
+				continue
+			}
+			visited[e.t] = true
+
+			for i := 0; i < e.t.NumField(); i++ {
+				field := e.t.Field(i)
+				field.Index = append(append([]int{}, e.index...), i)
+				tagName := jsonTagName(field)
+
+				if tagName == "-" || (!field.IsExported() && !(field.Anonymous && field.Type.Kind() == reflect.Struct)) {
+					continue
+				}
+
+				if isEmbeddedStruct(field) {
+					embeddedType := field.Type
+					if embeddedType.Kind() == reflect.Ptr {
+						embeddedType = embeddedType.Elem()
+					}
+					next = append(next, embedded{t: embeddedType, index: field.Index})
+					continue
+				}
+
+				if !field.IsExported() {
+					continue
+				}
+
+				name := tagName
+				if name == "" {
+					name = field.Name
+				}
+
+				if _, ok := candidates[name]; !ok {
+					names = append(names, name)
+				}
+
+				candidates[name] = append(candidates[name], jsonField{name: name, field: field, tagged: tagName != ""})
+
+				// a struct embedded twice at the same depth conflicts with itself, so its fields are hidden
+				if count[e.t] > 1 {
+					candidates[name] = append(candidates[name], jsonField{name: name, field: field, tagged: tagName != ""})
+				}
+			}
+		}
+
+		level = next
+	}
+
+	fields := make([]jsonField, 0, len(names))
+
+	for _, name := range names {
+		if field, ok := dominantField(candidates[name]); ok {
+			fields = append(fields, field)
+		}
+	}
+
+	sort.SliceStable(fields, func(i, j int) bool {
+		return lessIndex(fields[i].field.Index, fields[j].field.Index)
+	})
+
+	return fields
+}
+
+// dominantField picks the field encoding/json uses for a name. Candidates are ordered by depth since embedded structs are walked
+// breadth first.
+func dominantField(candidates []jsonField) (jsonField, bool) {
+	depth := len(candidates[0].field.Index)
+	shallowest := ext.Where(candidates, func(candidate jsonField) bool {
+		return len(candidate.field.Index) == depth
+	})
+
+	if len(shallowest) == 1 {
+		return shallowest[0], true
+	}
+
+	tagged := ext.Where(shallowest, func(candidate jsonField) bool {
+		return candidate.tagged
+	})
+
+	if len(tagged) == 1 {
+		return tagged[0], true
+	}
+
+	return jsonField{}, false
+}
+
+func lessIndex(a, b []int) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
+
+// fieldByIndex returns the value of a possibly promoted field, or an invalid value when an embedded pointer on the way is nil.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for _, i := range index {
+		if v.Kind() == reflect.Ptr {
+			if !v.IsValid() || v.IsNil() {
+				return reflect.Value{}
+			}
+			v = v.Elem()
+		}
+		if !v.IsValid() {
+			return reflect.Value{}
+		}
+		v = v.Field(i)
+	}
+	return v
+}
+
+// parameterName is shared between documentation and binding so the documented and parsed parameter names cannot drift.
+// The name tag wins over the json tag since parameter names, such as headers, often differ from the body representation.
+// Body properties are named by jsonFields instead, the way encoding/json decodes them.
+func parameterName(field reflect.StructField, namingStrategy NamingStrategy) (string, bool) {
+	if field.Tag.Get("name") != "" {
+		return field.Tag.Get("name"), true
+	}
+
+	tagName := jsonTagName(field)
+
+	if tagName == "-" {
+		return "", false
+	}
+
+	if tagName != "" {
+		return tagName, true
+	}
+
+	return namingStrategy(field.Name), true
+}
+
+type namingStrategyContextKey struct{}
+
+func namingStrategyFromContext(ctx context.Context) NamingStrategy {
+	if namingStrategy, ok := ctx.Value(namingStrategyContextKey{}).(NamingStrategy); ok {
+		return namingStrategy
+	}
+	return FieldNameStrategy
+}
+
+// SchemaNamingStrategy names the component schema of a type that has no alias and no SchemaName method.
+type SchemaNamingStrategy func(t reflect.Type) string
+
+// SchemaNamer lets a type choose its own component schema name.
+type SchemaNamer interface {
+	SchemaName() string
+}
+
+var schemaNamerType = reflect.TypeOf((*SchemaNamer)(nil)).Elem()
+
+// ShortSchemaName uses the type name without its package, so billing.Invoice becomes Invoice. This is the default.
+func ShortSchemaName(t reflect.Type) string {
+	if t.Name() != "" {
+		return t.Name()
+	}
+	return t.String()
+}
+
+// PackageQualifiedSchemaName prefixes the type name with its package name, so billing.Invoice stays billing.Invoice.
+func PackageQualifiedSchemaName(t reflect.Type) string {
+	if t.Name() == "" || t.PkgPath() == "" {
+		return t.String()
+	}
+	packageName, _, _ := strings.Cut(t.String(), ".")
+	return fmt.Sprintf("%s.%s", packageName, t.Name())
+}
+
+// GenericSchemaNamingStrategy renders an instantiated generic type from its name and the rendered names of its type arguments.
+type GenericSchemaNamingStrategy func(name string, typeArguments []string) string
+
+// OfGenericSchemaName renders Page[User] as PageOfUser and Pair[User, Order] as PairOfUserAndOrder. This is the default.
+func OfGenericSchemaName(name string, typeArguments []string) string {
+	return fmt.Sprintf("%sOf%s", name, strings.Join(typeArguments, "And"))
+}
+
+// genericSchemaName turns the reflected name of a generic instantiation, such as Page[github.com/acme/api/models.User],
+// into a URI safe name. Names without type arguments are returned unchanged.
+func genericSchemaName(name string, genericNaming GenericSchemaNamingStrategy) string {
+	base, typeArguments, ok := splitTypeArguments(name)
+
+	if !ok {
+		return name
+	}
+
+	return genericNaming(base, ext.SliceMap(typeArguments, func(typeArgument string) string {
+		return typeArgumentName(typeArgument, genericNaming)
+	}))
+}
+
+func typeArgumentName(typeArgument string, genericNaming GenericSchemaNamingStrategy) string {
+	switch {
+	case strings.HasPrefix(typeArgument, "*"):
+		return typeArgumentName(typeArgument[1:], genericNaming)
+	case strings.HasPrefix(typeArgument, "map["):
+		end := closingBracket(typeArgument, len("map"))
+		return fmt.Sprintf("%sTo%sMap", typeArgumentName(typeArgument[len("map["):end], genericNaming), typeArgumentName(typeArgument[end+1:], genericNaming))
+	case strings.HasPrefix(typeArgument, "["):
+		end := closingBracket(typeArgument, 0)
+		return fmt.Sprintf("%sArray", typeArgumentName(typeArgument[end+1:], genericNaming))
+	}
+
+	base, typeArguments, generic := splitTypeArguments(typeArgument)
+
+	// drop the import path and package, keeping only the type name
+	base = base[strings.LastIndex(base, "/")+1:]
+	if _, typeName, ok := strings.Cut(base, "."); ok {
+		base = typeName
+	}
+
+	base = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, base)
+
+	if base != "" {
+		base = strings.ToUpper(base[:1]) + base[1:]
+	}
+
+	if !generic {
+		return base
+	}
+
+	return genericNaming(base, ext.SliceMap(typeArguments, func(typeArgument string) string {
+		return typeArgumentName(typeArgument, genericNaming)
+	}))
+}
+
+// splitTypeArguments splits Name[A, B] into Name and its top level type arguments.
+func splitTypeArguments(name string) (string, []string, bool) {
+	start := strings.Index(name, "[")
+
+	if start <= 0 || !strings.HasSuffix(name, "]") || closingBracket(name, start) != len(name)-1 {
+		return name, nil, false
+	}
+
+	typeArguments := make([]string, 0)
+	depth := 0
+	argumentStart := start + 1
+
+	for i := start + 1; i < len(name)-1; i++ {
+		switch name[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				typeArguments = append(typeArguments, strings.TrimSpace(name[argumentStart:i]))
+				argumentStart = i + 1
+			}
+		}
+	}
+
+	typeArguments = append(typeArguments, strings.TrimSpace(name[argumentStart:len(name)-1]))
+
+	return name[:start], typeArguments, true
+}
+
+// closingBracket returns the index of the bracket closing the one at start.
+func closingBracket(name string, start int) int {
+	depth := 0
+
+	for i := start; i < len(name); i++ {
+		switch name[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return len(name) - 1
+}
+
+func typeSchemaName(t reflect.Type) (string, bool) {
+	switch {
+	case t.Kind() != reflect.Interface && t.Implements(schemaNamerType):
+		return reflect.Zero(t).Interface().(SchemaNamer).SchemaName(), true
+	case t.Kind() != reflect.Interface && reflect.PointerTo(t).Implements(schemaNamerType):
+		return reflect.New(t).Interface().(SchemaNamer).SchemaName(), true
+	default:
+		return "", false
+	}
+}
+
+func qualifiedTypeName(t reflect.Type) string {
+	if t.PkgPath() == "" {
+		return t.String()
+	}
+	return fmt.Sprintf("%s.%s", t.PkgPath(), t.Name())
+}
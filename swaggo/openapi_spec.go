@@ -1,5 +1,14 @@
 package swaggo
 
+import "encoding/json"
+
+type OpenApiVersion string
+
+const (
+	OpenApi30 OpenApiVersion = "3.0.2"
+	OpenApi31 OpenApiVersion = "3.1.0"
+)
+
 type SwagDoc struct {
 	OpenAPIVersion string                     `json:"openapi"`
 	Info           Info                       `json:"info"`
@@ -52,6 +61,7 @@ type Path struct {
 	RequestBody *Body                 `json:"requestBody,omitempty"`
 	Responses   map[string]Response   `json:"responses,omitempty"`
 	Security    []map[string][]string `json:"security,omitempty"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
 }
 
 type Parameter struct {
@@ -59,6 +69,7 @@ type Parameter struct {
 	In          string `json:"in"`
 	Description string `json:"description"`
 	Required    bool   `json:"required"`
+	Deprecated  bool   `json:"deprecated,omitempty"`
 	Schema      Schema `json:"schema"`
 }
 
@@ -80,26 +91,105 @@ type Body struct {
 }
 
 type Content struct {
-	Schema Schema `json:"schema"`
+	Schema   Schema             `json:"schema"`
+	Examples map[string]Example `json:"examples,omitempty"`
+}
+
+// Example is a named payload shown in the media type's example dropdown. Value and ExternalValue are mutually exclusive.
+type Example struct {
+	Summary       string `json:"summary,omitempty"`
+	Description   string `json:"description,omitempty"`
+	Value         any    `json:"value,omitempty"`
+	ExternalValue string `json:"externalValue,omitempty"`
 }
 
 type Schema struct {
-	Type       string              `json:"type,omitempty"`
-	Items      *Schema             `json:"items,omitempty"`
-	Format     string              `json:"format,omitempty"`
-	Ref        string              `json:"$ref,omitempty"`
-	Required   []string            `json:"required,omitempty"`
-	Properties map[string]Property `json:"properties,omitempty"`
+	Type                 string              `json:"type,omitempty"`
+	Items                *Schema             `json:"items,omitempty"`
+	Format               string              `json:"format,omitempty"`
+	Default              any                 `json:"default,omitempty"`
+	Ref                  string              `json:"$ref,omitempty"`
+	AllOf                []Schema            `json:"allOf,omitempty"`
+	AnyOf                []Schema            `json:"anyOf,omitempty"`
+	OneOf                []Schema            `json:"oneOf,omitempty"`
+	Discriminator        *Discriminator      `json:"discriminator,omitempty"`
+	Nullable             bool                `json:"nullable,omitempty"`
+	Required             []string            `json:"required,omitempty"`
+	Properties           map[string]Property `json:"properties,omitempty"`
+	AdditionalProperties *Schema             `json:"additionalProperties,omitempty"`
+	Enum                 []any               `json:"enum,omitempty"`
+	Minimum              *float64            `json:"minimum,omitempty"`
+	Maximum              *float64            `json:"maximum,omitempty"`
+	MinLength            *int                `json:"minLength,omitempty"`
+	MaxLength            *int                `json:"maxLength,omitempty"`
+	Pattern              string              `json:"pattern,omitempty"`
+	MinItems             *int                `json:"minItems,omitempty"`
+	MaxItems             *int                `json:"maxItems,omitempty"`
+	typeArray            bool                // written as [type, "null"] for OpenAPI 3.1
 }
 
 type Property struct {
-	Type        string              `json:"type,omitempty"`
-	Properties  map[string]Property `json:"properties,omitempty"` // relevant for object type
-	Items       *Schema             `json:"items,omitempty"`
-	Description string              `json:"description,omitempty"`
-	Format      string              `json:"format,omitempty"`
-	Example     any                 `json:"example,omitempty"`
-	Enum        []string            `json:"enum,omitempty"`
+	Type                 string              `json:"type,omitempty"`
+	Ref                  string              `json:"$ref,omitempty"`
+	AllOf                []Schema            `json:"allOf,omitempty"`
+	AnyOf                []Schema            `json:"anyOf,omitempty"`
+	OneOf                []Schema            `json:"oneOf,omitempty"`
+	Discriminator        *Discriminator      `json:"discriminator,omitempty"`
+	Nullable             bool                `json:"nullable,omitempty"`
+	Properties           map[string]Property `json:"properties,omitempty"` // relevant for object type
+	Required             []string            `json:"required,omitempty"`
+	AdditionalProperties *Schema             `json:"additionalProperties,omitempty"`
+	Items                *Schema             `json:"items,omitempty"`
+	Description          string              `json:"description,omitempty"`
+	Format               string              `json:"format,omitempty"`
+	Default              any                 `json:"default,omitempty"`
+	Example              any                 `json:"example,omitempty"`
+	Deprecated           bool                `json:"deprecated,omitempty"`
+	ReadOnly             bool                `json:"readOnly,omitempty"`
+	WriteOnly            bool                `json:"writeOnly,omitempty"`
+	Enum                 []any               `json:"enum,omitempty"`
+	Minimum              *float64            `json:"minimum,omitempty"`
+	Maximum              *float64            `json:"maximum,omitempty"`
+	MinLength            *int                `json:"minLength,omitempty"`
+	MaxLength            *int                `json:"maxLength,omitempty"`
+	Pattern              string              `json:"pattern,omitempty"`
+	MinItems             *int                `json:"minItems,omitempty"`
+	MaxItems             *int                `json:"maxItems,omitempty"`
+	typeArray            bool                // written as [type, "null"] for OpenAPI 3.1
+}
+
+type Discriminator struct {
+	PropertyName string            `json:"propertyName"`
+	Mapping      map[string]string `json:"mapping,omitempty"`
+}
+
+// MarshalJSON writes nullable OpenAPI 3.1 schemas with a type array instead of the 3.0 nullable keyword.
+func (s Schema) MarshalJSON() ([]byte, error) {
+	type schema Schema
+
+	if !s.typeArray {
+		return json.Marshal(schema(s))
+	}
+
+	return json.Marshal(struct {
+		schema
+		Type     []string `json:"type"`
+		Nullable bool     `json:"nullable,omitempty"`
+	}{schema: schema(s), Type: []string{s.Type, "null"}})
+}
+
+func (p Property) MarshalJSON() ([]byte, error) {
+	type property Property
+
+	if !p.typeArray {
+		return json.Marshal(property(p))
+	}
+
+	return json.Marshal(struct {
+		property
+		Type     []string `json:"type"`
+		Nullable bool     `json:"nullable,omitempty"`
+	}{property: property(p), Type: []string{p.Type, "null"}})
 }
 
 type Components struct {
@@ -0,0 +1,280 @@
+package swaggo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+
+	"github.com/Pieeer1/Auto-SwagGo/internal/ext"
+)
+
+// Register adds a typed handler whose documentation is derived from the type parameters.
+// Fields of Req tagged in:"query", in:"path" or in:"header" become parameters and the field tagged in:"body" becomes the request body.
+// A Req without in tags is bound from the query for GET, HEAD and DELETE requests and from the body otherwise.
+// The optional request details act as a template for the summary, authentication and additional documented responses.
+func Register[Req any, Resp any](mux *SwaggoMux, method, path, version string, handler func(context.Context, Req) (Resp, error), requestDetails ...RequestDetails) {
+	rd := RequestDetails{}
+
+	if len(requestDetails) > 0 {
+		rd = requestDetails[0]
+	}
+
+	rd.Method = method
+
+	requestType := reflect.TypeOf((*Req)(nil)).Elem()
+	responseType := reflect.TypeOf((*Resp)(nil)).Elem()
+
+	binding, err := newRequestBinding(requestType, method)
+
+	if err != nil {
+		panic(fmt.Sprintf("swaggo: %s %s: %s", method, path, err.Error()))
+	}
+
+	successCode := successStatusCode(rd.Responses, responseType)
+
+	rd.Requests = withTemplateBody(binding.requests(), rd.Requests)
+
+	if len(ext.Where(rd.Requests, func(requestData RequestData) bool { return requestData.Type == BodySource })) > 1 {
+		panic(fmt.Sprintf("swaggo: %s %s: only one body request is allowed", method, path))
+	}
+	rd.Responses = documentedResponses(rd.Responses, successCode, responseType, len(rd.Requests) > 0)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		var req Req
+
+		if err := binding.bind(r, reflect.ValueOf(&req).Elem()); err != nil {
+			writeHandlerError(w, err)
+			return
+		}
+
+		resp, err := handler(r.Context(), req)
+
+		if err != nil {
+			writeHandlerError(w, err)
+			return
+		}
+
+		if successCode == http.StatusNoContent || isEmptyStruct(responseType) {
+			w.WriteHeader(successCode)
+			return
+		}
+
+		WriteJson(w, successCode, resp)
+	}, version, rd)
+}
+
+type requestBinding struct {
+	t            reflect.Type
+	sources      []RequestDataSource
+	body         bool
+	bodyIndex    []int // nil when the whole request is the body
+	bodyRequired bool
+}
+
+func newRequestBinding(t reflect.Type, method string) (*requestBinding, error) {
+	binding := &requestBinding{t: t}
+	bodyMethod := method != http.MethodGet && method != http.MethodHead && method != http.MethodDelete
+
+	if isEmptyStruct(t) {
+		return binding, nil
+	}
+
+	if t.Kind() != reflect.Struct {
+		if !bodyMethod {
+			return nil, fmt.Errorf("request type must be a struct to bind from the query. Got %s", t.Kind().String())
+		}
+		binding.body = true
+		binding.bodyRequired = true
+		return binding, nil
+	}
+
+	fields := make([]reflect.StructField, 0, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() || t.Field(i).Anonymous {
+			fields = append(fields, t.Field(i))
+		}
+	}
+
+	taggedFields := ext.Where(fields, func(field reflect.StructField) bool {
+		return field.Tag.Get("in") != ""
+	})
+
+	if len(taggedFields) == 0 && bodyMethod {
+		binding.body = true
+		binding.bodyRequired = true
+		return binding, nil
+	}
+
+	// parameters are bound and documented from the direct fields of the request only, so promoted fields would be lost
+	for _, field := range fields {
+		if field.Anonymous {
+			return nil, fmt.Errorf("field %s is embedded. Declare parameter fields on the request itself", field.Name)
+		}
+	}
+
+	if len(taggedFields) == 0 {
+		binding.sources = []RequestDataSource{QuerySource}
+		return binding, nil
+	}
+
+	for _, field := range fields {
+		switch RequestDataSource(field.Tag.Get("in")) {
+		case QuerySource, PathSource, HeaderSource:
+			binding.sources = append(binding.sources, RequestDataSource(field.Tag.Get("in")))
+		case BodySource:
+			if binding.body {
+				return nil, fmt.Errorf("only one body field is allowed")
+			}
+			binding.body = true
+			binding.bodyIndex = field.Index
+			binding.bodyRequired = field.Type.Kind() != reflect.Ptr
+		case "":
+			return nil, fmt.Errorf("field %s has no in tag", field.Name)
+		default:
+			return nil, fmt.Errorf("field %s has an unknown in tag %s", field.Name, field.Tag.Get("in"))
+		}
+	}
+
+	binding.sources = ext.Distinct(binding.sources)
+
+	return binding, nil
+}
+
+func (b *requestBinding) requests() []RequestData {
+	requests := ext.SliceMap(b.sources, func(source RequestDataSource) RequestData {
+		return RequestData{Type: source, Data: zeroData(b.t)}
+	})
+
+	if b.body {
+		bodyType := b.t
+		if b.bodyIndex != nil {
+			bodyType = b.t.FieldByIndex(b.bodyIndex).Type
+		}
+		requests = append(requests, RequestData{Type: BodySource, Data: zeroData(bodyType), Required: b.bodyRequired})
+	}
+
+	return requests
+}
+
+// bind fills the request from its parameters and body. Constraints have already been checked by the validation middleware.
+func (b *requestBinding) bind(r *http.Request, v reflect.Value) error {
+	validationErrors := make(ValidationErrors, 0)
+
+	for _, source := range b.sources {
+		validationErrors = append(validationErrors, bindParameters(r, source, v)...)
+	}
+
+	if len(validationErrors) > 0 {
+		return validationErrors
+	}
+
+	if !b.body || r.Body == nil {
+		return nil
+	}
+
+	target := v
+	if b.bodyIndex != nil {
+		target = v.FieldByIndex(b.bodyIndex)
+	}
+
+	if target.Kind() == reflect.Struct {
+		applyDefaults(target)
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(target.Addr().Interface()); err != nil && !errors.Is(err, io.EOF) {
+		return jsonBodyError(err)
+	}
+
+	return nil
+}
+
+// withTemplateBody appends the template requests. A template body without data describes the bound body instead,
+// lending it a description, content types and examples.
+func withTemplateBody(requests []RequestData, template []RequestData) []RequestData {
+	for _, templateRequest := range template {
+		if templateRequest.Type != BodySource || templateRequest.Data != nil {
+			requests = append(requests, templateRequest)
+			continue
+		}
+
+		for i := range requests {
+			if requests[i].Type == BodySource {
+				requests[i].Description = templateRequest.Description
+				requests[i].ContentType = templateRequest.ContentType
+				requests[i].Examples = templateRequest.Examples
+			}
+		}
+	}
+
+	return requests
+}
+
+func successStatusCode(responses []ResponseData, responseType reflect.Type) int {
+	for _, response := range responses {
+		if response.Code >= 200 && response.Code < 300 {
+			return response.Code
+		}
+	}
+
+	if isEmptyStruct(responseType) {
+		return http.StatusNoContent
+	}
+
+	return http.StatusOK
+}
+
+// documentedResponses completes the template responses with the success response, error bodies and the validation failure response.
+func documentedResponses(responses []ResponseData, successCode int, responseType reflect.Type, hasRequests bool) []ResponseData {
+	documented := make([]ResponseData, 0, len(responses)+2)
+	hasSuccess := false
+	hasValidation := false
+
+	for _, response := range responses {
+		switch {
+		case response.Code == successCode:
+			hasSuccess = true
+			if response.Data == nil && successCode != http.StatusNoContent && !isEmptyStruct(responseType) {
+				response.Data = zeroData(responseType)
+			}
+		case response.Code >= 400 && response.Data == nil:
+			response.Data = ErrorResponse{}
+		}
+
+		if response.Code == http.StatusUnprocessableEntity {
+			hasValidation = true
+		}
+
+		documented = append(documented, response)
+	}
+
+	if !hasSuccess {
+		success := ResponseData{Code: successCode}
+		if successCode != http.StatusNoContent && !isEmptyStruct(responseType) {
+			success.Data = zeroData(responseType)
+		}
+		documented = append([]ResponseData{success}, documented...)
+	}
+
+	if hasRequests && !hasValidation {
+		documented = append(documented, ResponseData{Code: http.StatusUnprocessableEntity, Data: ValidationErrorResponse{}})
+	}
+
+	return documented
+}
+
+func isEmptyStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.NumField() == 0
+}
+
+// zeroData returns a value of the type usable for documentation. Pointers are allocated so their schema can be reflected.
+func zeroData(t reflect.Type) any {
+	if t.Kind() == reflect.Ptr {
+		return reflect.New(t.Elem()).Interface()
+	}
+	return reflect.Zero(t).Interface()
+}
@@ -0,0 +1,87 @@
+package swaggo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// HttpError is returned from typed handlers to respond with a specific status code.
+// Data replaces the default ErrorResponse body when set.
+type HttpError struct {
+	Code    int
+	Message string
+	Data    any
+}
+
+func NewHttpError(code int, message string) *HttpError {
+	return &HttpError{Code: code, Message: message}
+}
+
+func (e *HttpError) Error() string {
+	return e.Message
+}
+
+type ErrorResponse struct {
+	Message string `json:"message"`
+}
+
+// WriteJson encodes data as the response body, leaving out properties tagged writeOnly:"true" or format:"password".
+// Data is encoded through a pointer, so marshalers with pointer receivers apply to its value fields as they do when decoding.
+func WriteJson(w http.ResponseWriter, code int, data any) error {
+	body, err := json.Marshal(addressable(data))
+
+	if err == nil && data != nil && hasWriteOnly(reflect.TypeOf(data), map[reflect.Type]bool{}) {
+		body, err = withoutWriteOnly(reflect.TypeOf(data), body)
+	}
+
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, err = w.Write(body)
+	return err
+}
+
+// addressable copies data behind a pointer, which lets encoding/json call pointer receiver methods on every field.
+func addressable(data any) any {
+	if data == nil {
+		return nil
+	}
+
+	value := reflect.New(reflect.TypeOf(data))
+	value.Elem().Set(reflect.ValueOf(data))
+	return value.Interface()
+}
+
+func writeHandlerError(w http.ResponseWriter, err error) {
+	var httpError *HttpError
+	var validationErrors ValidationErrors
+	var maxBytesError *http.MaxBytesError
+
+	if errors.As(err, &httpError) {
+		if httpError.Data != nil {
+			WriteJson(w, httpError.Code, httpError.Data)
+			return
+		}
+		WriteJson(w, httpError.Code, ErrorResponse{Message: httpError.Message})
+		return
+	}
+
+	if errors.As(err, &validationErrors) {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	if errors.As(err, &maxBytesError) {
+		WriteJson(w, http.StatusRequestEntityTooLarge, ErrorResponse{Message: fmt.Sprintf("request body must not exceed %d bytes", maxBytesError.Limit)})
+		return
+	}
+
+	WriteJson(w, http.StatusInternalServerError, ErrorResponse{Message: http.StatusText(http.StatusInternalServerError)})
+}
@@ -0,0 +1,500 @@
+package swaggo
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/Pieeer1/Auto-SwagGo/internal/ext"
+)
+
+// schemaGenerator collects the component schemas for a document. Every named struct is registered once and referenced by $ref,
+// which also terminates recursive types.
+type schemaGenerator struct {
+	embeddedStructMode EmbeddedStructMode
+	openApiVersion     OpenApiVersion
+	inferRequired      bool
+	schemaNaming       SchemaNamingStrategy
+	genericNaming      GenericSchemaNamingStrategy
+	schemaAliases      map[reflect.Type]string
+	schemas            map[string]Schema
+	componentTypes     map[string]reflect.Type
+	inProgress         map[reflect.Type]bool
+	dataDefaults       map[reflect.Type]reflect.Value // body data registered with DataDefaults
+}
+
+type EmbeddedStructMode string
+
+const (
+	FlattenEmbeddedStructs EmbeddedStructMode = "flatten" // promote the embedded fields like encoding/json
+	AllOfEmbeddedStructs   EmbeddedStructMode = "allOf"   // reference the embedded struct's component schema in an allOf
+)
+
+func (c *SwaggoMux) newSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{
+		embeddedStructMode: c.embeddedStructMode,
+		openApiVersion:     c.openApiVersion,
+		inferRequired:      c.inferRequired,
+		schemaNaming:       c.schemaNaming,
+		genericNaming:      c.genericNaming,
+		schemaAliases:      c.schemaAliases,
+		schemas:            make(map[string]Schema),
+		componentTypes:     make(map[string]reflect.Type),
+		inProgress:         make(map[reflect.Type]bool),
+		dataDefaults:       c.dataDefaults(),
+	}
+}
+
+// dataDefaults collects the body data registered with DataDefaults. Its component schema is shared, so the values document the
+// defaults of the type wherever it is used.
+func (c *SwaggoMux) dataDefaults() map[reflect.Type]reflect.Value {
+	dataDefaults := make(map[reflect.Type]reflect.Value)
+
+	for _, route := range c.routes {
+		for _, requestDetails := range route.RequestDetails {
+			for _, requestData := range requestDetails.Requests {
+				if requestData.Type != BodySource || !requestData.DataDefaults || requestData.Data == nil {
+					continue
+				}
+				if t, v, err := rawReflect(requestData.Data); err == nil {
+					dataDefaults[t] = v
+				}
+			}
+		}
+	}
+
+	return dataDefaults
+}
+
+func schemaRef(name string) string {
+	return fmt.Sprintf("#/components/schemas/%s", name)
+}
+
+// componentName names a type by its alias, its SchemaName method or the schema naming strategy, in that order.
+// Two different types resolving to the same name is an error rather than one silently replacing the other.
+func (g *schemaGenerator) componentName(t reflect.Type) (string, error) {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+
+	name, ok := g.schemaAliases[t]
+
+	if !ok {
+		name, ok = typeSchemaName(t)
+	}
+
+	if !ok {
+		name = genericSchemaName(g.schemaNaming(t), g.genericNaming)
+	}
+
+	if existing, ok := g.componentTypes[name]; ok && existing != t {
+		return "", fmt.Errorf("schema name %s is used by both %s and %s. Implement SchemaName, register an alias or configure package qualified schema names", name, qualifiedTypeName(existing), qualifiedTypeName(t))
+	}
+
+	g.componentTypes[name] = t
+
+	return name, nil
+}
+
+// componentRef registers a named struct or union under components/schemas and returns its reference.
+// A type that is still being generated further up the stack is only referenced.
+func (g *schemaGenerator) componentRef(t reflect.Type, v reflect.Value) (string, error) {
+	name, err := g.componentName(t)
+
+	if err != nil {
+		return "", err
+	}
+
+	if _, ok := g.schemas[name]; ok || g.inProgress[t] {
+		return schemaRef(name), nil
+	}
+
+	g.inProgress[t] = true
+	schema, err := g.namedSchema(t, v)
+	delete(g.inProgress, t)
+
+	if err != nil {
+		return "", err
+	}
+
+	g.schemas[name] = schema
+
+	return schemaRef(name), nil
+}
+
+// dataName names the request body component of registered data.
+func (g *schemaGenerator) dataName(data any) (string, error) {
+	if union, ok := data.(Union); ok {
+		return g.unionName(union)
+	}
+	return g.bodyName(reflect.TypeOf(data))
+}
+
+// bodyName names containers after their elements the way generic type arguments are rendered, such as PriceArray or
+// StringToPriceMap, so a container body does not replace the body of its element.
+func (g *schemaGenerator) bodyName(t reflect.Type) (string, error) {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return g.bodyName(t.Elem())
+	case reflect.Slice, reflect.Array:
+		name, err := g.bodyName(t.Elem())
+		return fmt.Sprintf("%sArray", name), err
+	case reflect.Map:
+		name, err := g.bodyName(t.Elem())
+		return fmt.Sprintf("%sTo%sMap", typeArgumentName(t.Key().String(), g.genericNaming), name), err
+	default:
+		return g.componentName(t)
+	}
+}
+
+// namedSchema builds the component schema of a named struct or of a type registered as a union.
+func (g *schemaGenerator) namedSchema(t reflect.Type, v reflect.Value) (Schema, error) {
+	if union, ok := typeUnion(t); ok {
+		return g.unionSchema(union)
+	}
+	return g.structSchema(t, v)
+}
+
+// dataSchema maps the registered data of a request, response or header to the schema used in its content.
+// Top level pointers are dereferenced since a registered pointer does not make the content nullable.
+func (g *schemaGenerator) dataSchema(data any) (Schema, error) {
+	if union, ok := data.(Union); ok {
+		return g.unionSchema(union)
+	}
+
+	return g.parameterSchema(reflect.TypeOf(data), reflect.ValueOf(data))
+}
+
+// parameterSchema maps a type without marking a pointer as nullable. Parameters are either present or absent, never null.
+func (g *schemaGenerator) parameterSchema(t reflect.Type, v reflect.Value) (Schema, error) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		if v.IsValid() && !v.IsNil() {
+			v = v.Elem()
+		} else {
+			v = reflect.Value{}
+		}
+	}
+
+	return g.schemaFor(t, v)
+}
+
+// nullable marks a schema as accepting null. References cannot carry siblings, so they are wrapped.
+func (g *schemaGenerator) nullable(schema Schema) Schema {
+	if schema.Type == "" {
+		if g.openApiVersion == OpenApi31 {
+			return Schema{AnyOf: []Schema{schema, {Type: "null"}}}
+		}
+		return Schema{AllOf: []Schema{schema}, Nullable: true}
+	}
+
+	if g.openApiVersion == OpenApi31 {
+		schema.typeArray = true
+	} else {
+		schema.Nullable = true
+	}
+
+	return schema
+}
+
+// schemaFor maps a type to an inline schema. The value is only used for examples and may be invalid.
+func (g *schemaGenerator) schemaFor(t reflect.Type, v reflect.Value) (Schema, error) {
+	if t.Kind() == reflect.Ptr {
+		if schema, ok := pointerMarshalerSchema(t); ok {
+			return g.nullable(schema), nil
+		}
+		schema, err := g.parameterSchema(t, v)
+		return g.nullable(schema), err
+	}
+
+	if schema, ok := customTypeSchema(t); ok {
+		if !schema.Nullable {
+			return schema, nil
+		}
+		schema.Nullable = false
+		return g.nullable(schema), nil
+	}
+
+	if union, ok := typeUnion(t); ok && t.Name() == "" {
+		return g.unionSchema(union)
+	} else if ok {
+		ref, err := g.componentRef(t, v)
+		return Schema{Ref: ref}, err
+	}
+
+	switch {
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8: // uint8 is byte in reflect package
+		return Schema{Type: "string", Format: "binary"}, nil
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		var itemValue reflect.Value
+		if v.IsValid() && v.Len() > 0 {
+			itemValue = v.Index(0)
+		}
+
+		items, err := g.schemaFor(t.Elem(), itemValue)
+
+		if err != nil {
+			return Schema{}, err
+		}
+
+		return Schema{Type: "array", Items: &items}, nil
+	case t.Kind() == reflect.Map:
+		var valueValue reflect.Value
+		if v.IsValid() && v.Len() > 0 {
+			valueValue = v.MapIndex(v.MapKeys()[0])
+		}
+
+		additionalProperties, err := g.schemaFor(t.Elem(), valueValue)
+
+		if err != nil {
+			return Schema{}, err
+		}
+
+		return Schema{Type: "object", AdditionalProperties: &additionalProperties}, nil
+	case t.Kind() == reflect.Struct && t.Name() == "": // anonymous structs have no name to reference
+		return g.structSchema(t, v)
+	case t.Kind() == reflect.Struct:
+		ref, err := g.componentRef(t, v)
+		return Schema{Ref: ref}, err
+	default:
+		schema := primitiveSchema(t)
+		schema.Enum = typeEnumValues(t)
+		return schema, nil
+	}
+}
+
+// primitiveSchema maps a scalar type to its OpenAPI type and format. Small integer kinds carry their implicit bounds.
+func primitiveSchema(t reflect.Type) Schema {
+	switch t.Kind() {
+	case reflect.Int8:
+		return integerSchema("int32", ext.ToPtr(float64(math.MinInt8)), ext.ToPtr(float64(math.MaxInt8)))
+	case reflect.Int16:
+		return integerSchema("int32", ext.ToPtr(float64(math.MinInt16)), ext.ToPtr(float64(math.MaxInt16)))
+	case reflect.Int32:
+		return integerSchema("int32", nil, nil)
+	case reflect.Int, reflect.Int64:
+		return integerSchema("int64", nil, nil)
+	case reflect.Uint8:
+		return integerSchema("int32", ext.ToPtr(0.0), ext.ToPtr(float64(math.MaxUint8)))
+	case reflect.Uint16:
+		return integerSchema("int32", ext.ToPtr(0.0), ext.ToPtr(float64(math.MaxUint16)))
+	case reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return integerSchema("int64", ext.ToPtr(0.0), nil)
+	case reflect.Float32:
+		return Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return Schema{Type: "number", Format: "double"}
+	default:
+		return Schema{Type: parseGOTypeToSwaggerType(t.Kind(), t)}
+	}
+}
+
+func integerSchema(format string, minimum, maximum *float64) Schema {
+	return Schema{Type: "integer", Format: format, Minimum: minimum, Maximum: maximum}
+}
+
+func (g *schemaGenerator) structSchema(t reflect.Type, v reflect.Value) (Schema, error) {
+	schema := Schema{
+		Type:       "object",
+		Properties: make(map[string]Property),
+		Required:   make([]string, 0),
+	}
+
+	bases, err := g.addProperties(&schema, t, v)
+
+	if err != nil {
+		return Schema{}, err
+	}
+
+	if len(bases) == 0 {
+		return schema, nil
+	}
+
+	return Schema{AllOf: append(bases, schema)}, nil
+}
+
+// addProperties adds the fields of a struct to the schema and returns the allOf bases of its embedded structs.
+// Promoted fields come from jsonFields, the same list the validator uses, so shadowed fields resolve as in encoding/json.
+func (g *schemaGenerator) addProperties(schema *Schema, t reflect.Type, v reflect.Value) ([]Schema, error) {
+	bases := make([]Schema, 0)
+	fields := jsonFields(t)
+
+	if g.embeddedStructMode == AllOfEmbeddedStructs {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+
+			if !isEmbeddedStruct(field) {
+				continue
+			}
+
+			embeddedType := field.Type
+			embeddedValue := fieldByIndex(v, field.Index)
+
+			if embeddedType.Kind() == reflect.Ptr {
+				embeddedType = embeddedType.Elem()
+				if embeddedValue.IsValid() && !embeddedValue.IsNil() {
+					embeddedValue = embeddedValue.Elem()
+				} else {
+					embeddedValue = reflect.Value{}
+				}
+			}
+
+			ref, err := g.componentRef(embeddedType, embeddedValue)
+
+			if err != nil {
+				return nil, err
+			}
+
+			bases = append(bases, Schema{Ref: ref})
+		}
+
+		// promoted fields are documented by the component schemas of the bases
+		fields = ext.Where(fields, func(field jsonField) bool {
+			return len(field.field.Index) == 1
+		})
+	}
+
+	for _, entry := range fields {
+		field := entry.field
+
+		fName := entry.name
+
+		if g.isRequired(field) {
+			schema.Required = append(schema.Required, fName)
+		}
+
+		constraints, err := parseFieldConstraints(field)
+
+		if err != nil {
+			return nil, err
+		}
+
+		value := fieldByIndex(v, field.Index)
+
+		var defaultValue reflect.Value
+		if dataDefaults, ok := g.dataDefaults[t]; ok {
+			defaultValue = fieldByIndex(dataDefaults, field.Index)
+		}
+
+		propertyDefault, err := documentedDefault(field, defaultValue, defaultValue.IsValid())
+
+		if err != nil {
+			return nil, err
+		}
+
+		fieldSchema, err := g.schemaFor(field.Type, value)
+
+		if err != nil {
+			return nil, err
+		}
+
+		property := propertyFromSchema(fieldSchema)
+		property.Description = field.Tag.Get("description")
+		property.Example = primitiveExample(fieldSchema, value)
+		property.Default = propertyDefault
+		property.Deprecated = field.Tag.Get("deprecated") == "true"
+		property.ReadOnly = isReadOnly(field)
+		property.WriteOnly = isWriteOnly(field)
+
+		schema.Properties[fName] = propertyWithoutRefSiblings(constraints.applyToProperty(property))
+	}
+
+	return bases, nil
+}
+
+func (g *schemaGenerator) isRequired(field reflect.StructField) bool {
+	if field.Tag.Get("required") != "" {
+		return field.Tag.Get("required") == "true"
+	}
+
+	_, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+
+	return g.inferRequired && field.Type.Kind() != reflect.Ptr && !ext.Contains(strings.Split(options, ","), "omitempty")
+}
+
+// isEmbeddedStruct reports whether encoding/json would promote the fields of an embedded struct.
+func isEmbeddedStruct(field reflect.StructField) bool {
+	if !field.Anonymous || jsonTagName(field) != "" {
+		return false
+	}
+
+	t := field.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Struct
+}
+
+func propertyFromSchema(schema Schema) Property {
+	return Property{
+		Type:                 schema.Type,
+		Ref:                  schema.Ref,
+		Properties:           schema.Properties,
+		Required:             schema.Required,
+		Items:                schema.Items,
+		Format:               schema.Format,
+		AllOf:                schema.AllOf,
+		AnyOf:                schema.AnyOf,
+		OneOf:                schema.OneOf,
+		Discriminator:        schema.Discriminator,
+		Nullable:             schema.Nullable,
+		typeArray:            schema.typeArray,
+		Enum:                 schema.Enum,
+		Minimum:              schema.Minimum,
+		Maximum:              schema.Maximum,
+		MinLength:            schema.MinLength,
+		MaxLength:            schema.MaxLength,
+		Pattern:              schema.Pattern,
+		MinItems:             schema.MinItems,
+		MaxItems:             schema.MaxItems,
+		AdditionalProperties: schema.AdditionalProperties,
+	}
+}
+
+// propertyWithoutRefSiblings wraps a reference in allOf when the property also sets other keywords, such as a description or readOnly,
+// since OpenAPI 3.0 ignores every sibling of $ref.
+func propertyWithoutRefSiblings(property Property) Property {
+	if property.Ref == "" || reflect.DeepEqual(property, Property{Ref: property.Ref}) {
+		return property
+	}
+
+	property.AllOf = []Schema{{Ref: property.Ref}}
+	property.Ref = ""
+
+	return property
+}
+
+// schemaWithoutRefSiblings wraps a reference in allOf when the schema also sets other keywords, such as a parameter default.
+func schemaWithoutRefSiblings(schema Schema) Schema {
+	if schema.Ref == "" || reflect.DeepEqual(schema, Schema{Ref: schema.Ref}) {
+		return schema
+	}
+
+	schema.AllOf = []Schema{{Ref: schema.Ref}}
+	schema.Ref = ""
+
+	return schema
+}
+
+// primitiveExample uses the registered value of a scalar field as its example.
+func primitiveExample(schema Schema, v reflect.Value) any {
+	if !v.IsValid() || (schema.Type == "string" && schema.Format != "") {
+		return nil
+	}
+
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch schema.Type {
+	case "string", "integer", "number", "boolean":
+		return autoType(v.Kind(), v)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,276 @@
+package tests
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Pieeer1/Auto-SwagGo/swaggo"
+)
+
+func verifyToken(expected string) swaggo.AuthVerifier {
+	return func(r *http.Request, credential string) (swaggo.Principal, error) {
+		if credential != expected {
+			return swaggo.Principal{}, errors.New("invalid credential")
+		}
+		return swaggo.Principal{Subject: credential}, nil
+	}
+}
+
+func TestBearerAuthVerifier(t *testing.T) {
+	var subject string
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := swaggo.PrincipalFromContext(r.Context())
+		if ok {
+			subject = principal.Subject
+		}
+		w.WriteHeader(http.StatusOK)
+	}, "", swaggo.RequestDetails{
+		Method: "GET",
+		AuthenticationConfiguration: &swaggo.AuthenticationConfiguration{
+			BearerAuth: &swaggo.BearerAuth{Verify: verifyToken("token")},
+		},
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/api/test", nil)
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401, got %d", recorder.Code)
+	}
+
+	if recorder.Header().Get("WWW-Authenticate") != "Bearer" {
+		t.Errorf("Expected Bearer challenge, got %s", recorder.Header().Get("WWW-Authenticate"))
+	}
+
+	request = httptest.NewRequest(http.MethodGet, "/api/test", nil)
+	request.Header.Set("Authorization", "Bearer wrong")
+	recorder = httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401, got %d", recorder.Code)
+	}
+
+	if recorder.Header().Get("WWW-Authenticate") != `Bearer error="invalid_token"` {
+		t.Errorf("Expected invalid_token challenge, got %s", recorder.Header().Get("WWW-Authenticate"))
+	}
+
+	request = httptest.NewRequest(http.MethodGet, "/api/test", nil)
+	request.Header.Set("Authorization", "Bearer token")
+	recorder = httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected 200, got %d", recorder.Code)
+	}
+
+	if subject != "token" {
+		t.Errorf("Expected principal on context, got %s", subject)
+	}
+}
+
+func TestBasicAndApiKeyAuthVerifiers(t *testing.T) {
+	var subject string
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := swaggo.PrincipalFromContext(r.Context())
+		if ok {
+			subject = principal.Subject
+		}
+		w.WriteHeader(http.StatusOK)
+	}, "", swaggo.RequestDetails{
+		Method: "GET",
+		AuthenticationConfiguration: &swaggo.AuthenticationConfiguration{
+			BasicAuth:  &swaggo.BasicAuth{Realm: "test", Verify: verifyToken("user:pass")},
+			ApiKeyAuth: &swaggo.ApiKeyAuth{In: "query", Name: "key", Verify: verifyToken("secret")},
+		},
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/api/test", nil)
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401, got %d", recorder.Code)
+	}
+
+	challenges := recorder.Header().Values("WWW-Authenticate")
+
+	if len(challenges) != 2 || challenges[0] != `Basic realm="test"` {
+		t.Errorf("Expected basic and api key challenges, got %v", challenges)
+	}
+
+	request = httptest.NewRequest(http.MethodGet, "/api/test", nil)
+	request.SetBasicAuth("user", "pass")
+	recorder = httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK || subject != "user:pass" {
+		t.Errorf("Expected basic auth to succeed, got %d %s", recorder.Code, subject)
+	}
+
+	request = httptest.NewRequest(http.MethodGet, "/api/test?key=secret", nil)
+	recorder = httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK || subject != "secret" {
+		t.Errorf("Expected api key auth to succeed, got %d %s", recorder.Code, subject)
+	}
+}
+
+func TestAuthWithoutVerifierIsDocumentationOnly(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}, "", swaggo.RequestDetails{
+		Method: "GET",
+		AuthenticationConfiguration: &swaggo.AuthenticationConfiguration{
+			BearerAuth: &swaggo.BearerAuth{},
+		},
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/api/test", nil)
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected 200, got %d", recorder.Code)
+	}
+}
+
+func TestAllScopesAuthorization(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}, "", swaggo.RequestDetails{
+		Method: "GET",
+		AuthenticationConfiguration: &swaggo.AuthenticationConfiguration{
+			Oauth2Auth: &swaggo.Oauth2Auth{
+				Verify: func(r *http.Request, credential string) (swaggo.Principal, error) {
+					return swaggo.Principal{Subject: "user", Scopes: []string{credential}}, nil
+				},
+			},
+		},
+		OauthScopes:     []string{"read", "write"},
+		OauthScopeMatch: swaggo.AllScopes,
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/api/test", nil)
+	request.Header.Set("Authorization", "Bearer read")
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("Expected 403, got %d", recorder.Code)
+	}
+}
+
+func TestAnyScopeAuthorization(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}, "", swaggo.RequestDetails{
+		Method: "GET",
+		AuthenticationConfiguration: &swaggo.AuthenticationConfiguration{
+			Oauth2Auth: &swaggo.Oauth2Auth{
+				Verify: func(r *http.Request, credential string) (swaggo.Principal, error) {
+					return swaggo.Principal{Subject: "user", Scopes: []string{credential}}, nil
+				},
+			},
+		},
+		OauthScopes:     []string{"read", "write"},
+		OauthScopeMatch: swaggo.AnyScope,
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/api/test", nil)
+	request.Header.Set("Authorization", "Bearer read")
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected 200, got %d", recorder.Code)
+	}
+
+	request = httptest.NewRequest(http.MethodGet, "/api/test", nil)
+	request.Header.Set("Authorization", "Bearer delete")
+	recorder = httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("Expected 403, got %d", recorder.Code)
+	}
+
+	doc, err := swaggoMux.MapDoc("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(doc.Paths["/api/test"]["get"].Security) != 2 {
+		t.Errorf("Expected one security requirement per scope, got %v", doc.Paths["/api/test"]["get"].Security)
+	}
+}
+
+func TestCustomAuthorizationCallback(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}, "", swaggo.RequestDetails{
+		Method: "GET",
+		AuthenticationConfiguration: &swaggo.AuthenticationConfiguration{
+			Oauth2Auth: &swaggo.Oauth2Auth{
+				Verify: func(r *http.Request, credential string) (swaggo.Principal, error) {
+					return swaggo.Principal{Subject: "user", Scopes: []string{credential}}, nil
+				},
+			},
+			Authorize: func(r *http.Request, principal swaggo.Principal, scopes []string, scopeMatch swaggo.ScopeMatch) error {
+				if principal.Scopes[0] != "admin" {
+					return errors.New("forbidden")
+				}
+				return nil
+			},
+		},
+		OauthScopes:     []string{"read", "write"},
+		OauthScopeMatch: swaggo.AllScopes,
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/api/test", nil)
+	request.Header.Set("Authorization", "Bearer admin")
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected 200, got %d", recorder.Code)
+	}
+
+	request = httptest.NewRequest(http.MethodGet, "/api/test", nil)
+	request.Header.Set("Authorization", "Bearer read")
+	recorder = httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("Expected 403, got %d", recorder.Code)
+	}
+}
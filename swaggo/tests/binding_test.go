@@ -0,0 +1,258 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Pieeer1/Auto-SwagGo/swaggo"
+)
+
+type BindingTestQuery struct {
+	Search  string    `json:"search" name:"q" required:"true"`
+	Page    int       `json:"page"`
+	Ratio   float64   `json:"ratio"`
+	Active  bool      `json:"active"`
+	Since   time.Time `json:"since"`
+	Tags    []string  `json:"tags"`
+	Limit   *uint     `json:"limit"`
+	private string
+}
+
+type BindingTestPath struct {
+	Id  int   `name:"id" required:"true"`
+	Ids []int `name:"ids"`
+}
+
+type BindingTestHeader struct {
+	RequestId string   `name:"X-Request-Id" required:"true"`
+	Accept    []string `name:"Accept"`
+}
+
+func TestBindQuery(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "/test?q=example&page=2&ratio=0.5&active=true&since=2024-01-01T00:00:00Z&tags=a&tags=b&limit=10", nil)
+
+	query, err := swaggo.BindQuery[BindingTestQuery](request)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if query.Search != "example" {
+		t.Errorf("Expected example, got %s", query.Search)
+	}
+
+	if query.Page != 2 {
+		t.Errorf("Expected 2, got %d", query.Page)
+	}
+
+	if query.Ratio != 0.5 {
+		t.Errorf("Expected 0.5, got %f", query.Ratio)
+	}
+
+	if !query.Active {
+		t.Errorf("Expected true, got false")
+	}
+
+	if !query.Since.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected 2024-01-01, got %s", query.Since)
+	}
+
+	if len(query.Tags) != 2 || query.Tags[0] != "a" || query.Tags[1] != "b" {
+		t.Errorf("Expected [a b], got %v", query.Tags)
+	}
+
+	if query.Limit == nil || *query.Limit != 10 {
+		t.Errorf("Expected 10, got %v", query.Limit)
+	}
+}
+
+func TestBindQueryReportsInvalidValues(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "/test?page=two&limit=-1", nil)
+
+	_, err := swaggo.BindQuery[BindingTestQuery](request)
+
+	var validationErrors swaggo.ValidationErrors
+
+	if !errors.As(err, &validationErrors) {
+		t.Fatalf("Expected validation errors, got %v", err)
+	}
+
+	if len(validationErrors) != 3 {
+		t.Fatalf("Expected 3 errors, got %d: %v", len(validationErrors), validationErrors)
+	}
+
+	if validationErrors[0].Field != "q" || validationErrors[0].Message != "is required" {
+		t.Errorf("Expected q is required, got %v", validationErrors[0])
+	}
+
+	if validationErrors[1].Field != "page" {
+		t.Errorf("Expected page, got %s", validationErrors[1].Field)
+	}
+
+	if validationErrors[2].Field != "limit" {
+		t.Errorf("Expected limit, got %s", validationErrors[2].Field)
+	}
+}
+
+func TestBindPathAndHeader(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{"v1"})
+
+	var path BindingTestPath
+	var header BindingTestHeader
+	var pathErr, headerErr error
+
+	swaggoMux.HandleFunc("/test/{id}/{ids}", func(w http.ResponseWriter, r *http.Request) {
+		path, pathErr = swaggo.BindPath[BindingTestPath](r)
+		header, headerErr = swaggo.BindHeader[BindingTestHeader](r)
+		w.WriteHeader(http.StatusOK)
+	}, "v1", swaggo.RequestDetails{
+		Method: "GET",
+		Requests: []swaggo.RequestData{
+			{Type: swaggo.PathSource, Data: BindingTestPath{}},
+			{Type: swaggo.HeaderSource, Data: BindingTestHeader{}},
+		},
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/api/v1/test/5/1,2,3", nil)
+	request.Header.Set("X-Request-Id", "abc")
+	request.Header.Set("Accept", "application/json, text/plain")
+	swaggoMux.ServeHTTP(httptest.NewRecorder(), request)
+
+	if pathErr != nil {
+		t.Fatal(pathErr)
+	}
+
+	if headerErr != nil {
+		t.Fatal(headerErr)
+	}
+
+	if path.Id != 5 {
+		t.Errorf("Expected 5, got %d", path.Id)
+	}
+
+	if len(path.Ids) != 3 || path.Ids[2] != 3 {
+		t.Errorf("Expected [1 2 3], got %v", path.Ids)
+	}
+
+	if header.RequestId != "abc" {
+		t.Errorf("Expected abc, got %s", header.RequestId)
+	}
+
+	if len(header.Accept) != 2 || header.Accept[1] != "text/plain" {
+		t.Errorf("Expected [application/json text/plain], got %v", header.Accept)
+	}
+}
+
+type BindingTestDefaultQuery struct {
+	PageSize int      `name:"pageSize" default:"20"`
+	Sort     string   `name:"sort"`
+	Fields   []string `name:"fields" default:"id, name"`
+	Verbose  *bool    `name:"verbose"`
+}
+
+type BindingTestDefaultOptions struct {
+	Notify bool `json:"notify" default:"true"`
+}
+
+type BindingTestDefaultBody struct {
+	Name     string                    `json:"name"`
+	Priority int                       `json:"priority" default:"3"`
+	Labels   []string                  `json:"labels"`
+	Options  BindingTestDefaultOptions `json:"options"`
+}
+
+var bindingTestDefaultLabels = []string{"new"}
+
+func TestDefaultsAreDocumented(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	verbose := true
+
+	swaggoMux.HandleFunc("/test", nil, "", swaggo.RequestDetails{
+		Method: "POST",
+		Requests: []swaggo.RequestData{
+			{Type: swaggo.QuerySource, Data: BindingTestDefaultQuery{Sort: "name", Verbose: &verbose}, DataDefaults: true},
+			{Type: swaggo.BodySource, Data: BindingTestDefaultBody{Name: "untitled", Labels: bindingTestDefaultLabels}, DataDefaults: true},
+		},
+	})
+
+	doc, err := swaggoMux.MapDoc("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parameters := doc.Paths["/api/test"]["post"].Parameters
+
+	if parameters[0].Schema.Default != 20 || parameters[1].Schema.Default != "name" || fmt.Sprint(parameters[2].Schema.Default) != "[id name]" {
+		t.Errorf("Expected pageSize, sort and fields defaults, got %v, %v and %v", parameters[0].Schema.Default, parameters[1].Schema.Default, parameters[2].Schema.Default)
+	}
+
+	properties := doc.Components.Schemas["BindingTestDefaultBody"].Properties
+
+	if properties["name"].Default != "untitled" || properties["priority"].Default != 3 {
+		t.Errorf("Expected name and priority defaults, got %v and %v", properties["name"].Default, properties["priority"].Default)
+	}
+
+	if doc.Components.Schemas["BindingTestDefaultOptions"].Properties["notify"].Default != true {
+		t.Errorf("Expected nested notify default, got %+v", doc.Components.Schemas["BindingTestDefaultOptions"].Properties["notify"])
+	}
+}
+
+func TestDefaultsAreBound(t *testing.T) {
+	var query BindingTestDefaultQuery
+	var body BindingTestDefaultBody
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	verbose := true
+
+	swaggoMux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		query, _ = swaggo.BindQuery[BindingTestDefaultQuery](r)
+		body, _ = swaggo.Bind[BindingTestDefaultBody](r, swaggo.BodySource)
+		w.WriteHeader(http.StatusOK)
+	}, "", swaggo.RequestDetails{
+		Method: "POST",
+		Requests: []swaggo.RequestData{
+			{Type: swaggo.QuerySource, Data: BindingTestDefaultQuery{Sort: "name", Verbose: &verbose}, DataDefaults: true},
+			{Type: swaggo.BodySource, Data: BindingTestDefaultBody{Name: "untitled", Labels: bindingTestDefaultLabels}, DataDefaults: true},
+		},
+	})
+
+	request := httptest.NewRequest(http.MethodPost, "/api/test", strings.NewReader(`{"labels":["sent"]}`))
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	if query.PageSize != 20 || query.Sort != "name" || len(query.Fields) != 2 || query.Verbose == nil || !*query.Verbose {
+		t.Errorf("Expected query defaults, got %+v", query)
+	}
+
+	if body.Name != "untitled" || body.Priority != 3 || !body.Options.Notify || fmt.Sprint(body.Labels) != "[sent]" {
+		t.Errorf("Expected body defaults with the sent labels, got %+v", body)
+	}
+
+	request = httptest.NewRequest(http.MethodPost, "/api/test?pageSize=5&sort=date", strings.NewReader(`{"labels":["again"]}`))
+	swaggoMux.ServeHTTP(httptest.NewRecorder(), request)
+
+	if query.PageSize != 5 || query.Sort != "date" {
+		t.Errorf("Expected sent values to replace defaults, got %+v", query)
+	}
+
+	if fmt.Sprint(body.Labels) != "[again]" || fmt.Sprint(bindingTestDefaultLabels) != "[new]" {
+		t.Errorf("Expected the registered instance to be left untouched, got %v", body.Labels)
+	}
+}
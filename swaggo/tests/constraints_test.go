@@ -0,0 +1,311 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Pieeer1/Auto-SwagGo/swaggo"
+)
+
+type ConstraintTestBody struct {
+	Name   string   `json:"name" minLength:"2" maxLength:"5" pattern:"^[a-z]+$"`
+	Age    int      `json:"age" minimum:"0" maximum:"130"`
+	Email  string   `json:"email" format:"email"`
+	Status string   `json:"status" enum:"active,inactive"`
+	Tags   []string `json:"tags" minItems:"1" maxItems:"2" enum:"a,b,c"`
+}
+
+type ConstraintTestQuery struct {
+	Page  int    `name:"page" minimum:"1"`
+	Order string `name:"order" enum:"asc,desc"`
+}
+
+func TestConstraintTagsAreDocumented(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{"v1"})
+
+	swaggoMux.HandleFunc("/test", nil, "v1", swaggo.RequestDetails{
+		Method: "POST",
+		Requests: []swaggo.RequestData{
+			{Type: swaggo.QuerySource, Data: ConstraintTestQuery{}},
+			{Type: swaggo.BodySource, Data: ConstraintTestBody{}},
+		},
+	})
+
+	doc, err := swaggoMux.MapDoc("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	properties := doc.Components.Schemas["ConstraintTestBody"].Properties
+
+	if *properties["name"].MinLength != 2 || *properties["name"].MaxLength != 5 || properties["name"].Pattern != "^[a-z]+$" {
+		t.Errorf("Expected name length and pattern constraints, got %+v", properties["name"])
+	}
+
+	if *properties["age"].Minimum != 0 || *properties["age"].Maximum != 130 {
+		t.Errorf("Expected age bounds, got %+v", properties["age"])
+	}
+
+	if properties["email"].Format != "email" {
+		t.Errorf("Expected email format, got %s", properties["email"].Format)
+	}
+
+	if len(properties["status"].Enum) != 2 || properties["status"].Enum[0] != "active" {
+		t.Errorf("Expected status enum, got %v", properties["status"].Enum)
+	}
+
+	if *properties["tags"].MinItems != 1 || *properties["tags"].MaxItems != 2 || len(properties["tags"].Items.Enum) != 3 {
+		t.Errorf("Expected tags item constraints, got %+v", properties["tags"])
+	}
+
+	parameters := doc.Paths["/api/v1/test"]["post"].Parameters
+
+	if *parameters[0].Schema.Minimum != 1 {
+		t.Errorf("Expected page minimum, got %+v", parameters[0].Schema)
+	}
+
+	if len(parameters[1].Schema.Enum) != 2 || parameters[1].Schema.Enum[1] != "desc" {
+		t.Errorf("Expected order enum, got %v", parameters[1].Schema.Enum)
+	}
+}
+
+func TestInvalidConstraintTagFailsRegistration(t *testing.T) {
+	type InvalidConstraintChild struct {
+		Age int `minimum:"zero"`
+	}
+
+	type InvalidConstraintBody struct {
+		Children []InvalidConstraintChild `json:"children"`
+	}
+
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected invalid minimum tag to fail registration")
+		}
+	}()
+
+	swaggoMux.HandleFunc("/test", nil, "", swaggo.RequestDetails{
+		Method:   "POST",
+		Requests: []swaggo.RequestData{{Type: swaggo.BodySource, Data: InvalidConstraintBody{}}},
+	})
+}
+
+func TestConstraintTagsAreEnforced(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{"v1"})
+
+	swaggoMux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}, "v1", swaggo.RequestDetails{
+		Method: "POST",
+		Requests: []swaggo.RequestData{
+			{Type: swaggo.QuerySource, Data: ConstraintTestQuery{}},
+			{Type: swaggo.BodySource, Data: ConstraintTestBody{}},
+		},
+	})
+
+	request := httptest.NewRequest(http.MethodPost, "/api/v1/test?page=1", strings.NewReader(`{"name":"abc","age":30,"email":"a@b.co","status":"active","tags":["a"]}`))
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	request = httptest.NewRequest(http.MethodPost, "/api/v1/test?page=0&order=up", strings.NewReader(`{"name":"ABCDEFG","age":200,"email":"nope","status":"gone","tags":["a","d","c"]}`))
+	recorder = httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected 422, got %d", recorder.Code)
+	}
+
+	var response swaggo.ValidationErrorResponse
+
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+
+	failedFields := map[string]int{}
+
+	for _, validationError := range response.Errors {
+		failedFields[validationError.Field]++
+	}
+
+	expected := map[string]int{"page": 1, "order": 1, "name": 2, "age": 1, "email": 1, "status": 1, "tags": 1, "tags[1]": 1}
+
+	for field, count := range expected {
+		if failedFields[field] != count {
+			t.Errorf("Expected %d errors for %s, got %d", count, field, failedFields[field])
+		}
+	}
+}
+
+type ConstraintTestStatus string
+
+const (
+	ConstraintTestStatusActive   ConstraintTestStatus = "active"
+	ConstraintTestStatusInactive ConstraintTestStatus = "inactive"
+)
+
+func (ConstraintTestStatus) EnumValues() []any {
+	return []any{ConstraintTestStatusActive, ConstraintTestStatusInactive}
+}
+
+type ConstraintTestPriority int
+
+func (*ConstraintTestPriority) EnumValues() []any {
+	return []any{1, 2, 3}
+}
+
+type ConstraintTestEnumBody struct {
+	Status     ConstraintTestStatus   `json:"status"`
+	Priority   ConstraintTestPriority `json:"priority"`
+	Statuses   []ConstraintTestStatus `json:"statuses"`
+	Restricted ConstraintTestStatus   `json:"restricted" enum:"active"`
+}
+
+type ConstraintTestEnumQuery struct {
+	Status *ConstraintTestStatus `name:"status"`
+}
+
+func TestEnumValuesAreDocumented(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.HandleFunc("/test", nil, "", swaggo.RequestDetails{
+		Method: "POST",
+		Requests: []swaggo.RequestData{
+			{Type: swaggo.QuerySource, Data: ConstraintTestEnumQuery{}},
+			{Type: swaggo.BodySource, Data: ConstraintTestEnumBody{}},
+		},
+	})
+
+	doc, err := swaggoMux.MapDoc("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	properties := doc.Components.Schemas["ConstraintTestEnumBody"].Properties
+
+	if len(properties["status"].Enum) != 2 || properties["status"].Enum[0] != "active" {
+		t.Errorf("Expected status enum, got %v", properties["status"].Enum)
+	}
+
+	if len(properties["priority"].Enum) != 3 || properties["priority"].Enum[2] != int64(3) {
+		t.Errorf("Expected priority enum, got %v", properties["priority"].Enum)
+	}
+
+	if len(properties["statuses"].Items.Enum) != 2 {
+		t.Errorf("Expected statuses item enum, got %+v", properties["statuses"].Items)
+	}
+
+	if len(properties["restricted"].Enum) != 1 {
+		t.Errorf("Expected enum tag to override the type values, got %v", properties["restricted"].Enum)
+	}
+
+	parameters := doc.Paths["/api/test"]["post"].Parameters
+
+	if len(parameters[0].Schema.Enum) != 2 {
+		t.Errorf("Expected status parameter enum, got %v", parameters[0].Schema.Enum)
+	}
+}
+
+func TestEnumValuesAreEnforced(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}, "", swaggo.RequestDetails{
+		Method: "POST",
+		Requests: []swaggo.RequestData{
+			{Type: swaggo.QuerySource, Data: ConstraintTestEnumQuery{}},
+			{Type: swaggo.BodySource, Data: ConstraintTestEnumBody{}},
+		},
+	})
+
+	request := httptest.NewRequest(http.MethodPost, "/api/test?status=active", strings.NewReader(`{"status":"inactive","priority":2,"statuses":["active"],"restricted":"active"}`))
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	request = httptest.NewRequest(http.MethodPost, "/api/test?status=gone", strings.NewReader(`{"status":"gone","priority":4,"statuses":["gone"],"restricted":"inactive"}`))
+	recorder = httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	var response swaggo.ValidationErrorResponse
+
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+
+	if recorder.Code != http.StatusUnprocessableEntity || len(response.Errors) != 5 {
+		t.Errorf("Expected 5 enum errors, got %d: %v", recorder.Code, response.Errors)
+	}
+}
+
+type ConstraintTestEnumContainers struct {
+	ByName map[string]ConstraintTestStatus     `json:"byName"`
+	Nested [][]ConstraintTestStatus            `json:"nested"`
+	Lists  map[string][]ConstraintTestPriority `json:"lists"`
+}
+
+func TestEnumValuesAreEnforcedInEveryPosition(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	swaggoMux.HandleFunc("/status", handler, "", swaggo.RequestDetails{
+		Method:   "POST",
+		Requests: []swaggo.RequestData{{Type: swaggo.BodySource, Data: ConstraintTestStatus("")}},
+	})
+
+	swaggoMux.HandleFunc("/containers", handler, "", swaggo.RequestDetails{
+		Method:   "POST",
+		Requests: []swaggo.RequestData{{Type: swaggo.BodySource, Data: ConstraintTestEnumContainers{}}},
+	})
+
+	cases := []struct {
+		path     string
+		body     string
+		expected int
+	}{
+		{"/api/status", `"active"`, http.StatusOK},
+		{"/api/status", `"gone"`, http.StatusUnprocessableEntity},
+		{"/api/containers", `{"byName":{"a":"active"},"nested":[["inactive"]],"lists":{"a":[1,3]}}`, http.StatusOK},
+		{"/api/containers", `{"byName":{"a":"gone"}}`, http.StatusUnprocessableEntity},
+		{"/api/containers", `{"nested":[["active","gone"]]}`, http.StatusUnprocessableEntity},
+		{"/api/containers", `{"lists":{"a":[4]}}`, http.StatusUnprocessableEntity},
+	}
+
+	for _, c := range cases {
+		recorder := httptest.NewRecorder()
+		swaggoMux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, c.path, strings.NewReader(c.body)))
+
+		if recorder.Code != c.expected {
+			t.Errorf("Expected %d for %s %s, got %d: %s", c.expected, c.path, c.body, recorder.Code, recorder.Body.String())
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Pieeer1/Auto-SwagGo/swaggo"
+)
+
+func TestCorsPreflight(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{"v1"})
+
+	swaggoMux.ConfigureCors(&swaggo.CorsConfiguration{
+		AllowedOrigins: []string{"https://example.com"},
+		AllowedHeaders: []string{"Content-Type"},
+		MaxAge:         600,
+	})
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	swaggoMux.HandleFunc("/test", handler, "v1", swaggo.RequestDetails{Method: "GET"}, swaggo.RequestDetails{Method: "PUT"})
+
+	request := httptest.NewRequest(http.MethodOptions, "/api/v1/test", nil)
+	request.Header.Set("Origin", "https://example.com")
+	request.Header.Set("Access-Control-Request-Method", "GET")
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusNoContent {
+		t.Errorf("Expected 204, got %d", recorder.Code)
+	}
+
+	if recorder.Header().Get("Access-Control-Allow-Origin") != "https://example.com" {
+		t.Errorf("Expected origin to be allowed, got %s", recorder.Header().Get("Access-Control-Allow-Origin"))
+	}
+
+	if recorder.Header().Get("Access-Control-Allow-Methods") != "GET, PUT" {
+		t.Errorf("Expected GET, PUT, got %s", recorder.Header().Get("Access-Control-Allow-Methods"))
+	}
+
+	if recorder.Header().Get("Access-Control-Allow-Headers") != "Content-Type" {
+		t.Errorf("Expected Content-Type, got %s", recorder.Header().Get("Access-Control-Allow-Headers"))
+	}
+
+	if recorder.Header().Get("Access-Control-Max-Age") != "600" {
+		t.Errorf("Expected 600, got %s", recorder.Header().Get("Access-Control-Max-Age"))
+	}
+
+	request = httptest.NewRequest(http.MethodOptions, "/api/v1/test", nil)
+	request.Header.Set("Origin", "https://evil.com")
+	request.Header.Set("Access-Control-Request-Method", "GET")
+	recorder = httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("Expected origin to be rejected, got %s", recorder.Header().Get("Access-Control-Allow-Origin"))
+	}
+}
+
+func TestCorsRequestDetailsOverride(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{"v1"})
+
+	swaggoMux.ConfigureCors(&swaggo.CorsConfiguration{
+		AllowedOrigins: []string{"https://example.com"},
+		AllowedHeaders: []string{"Content-Type"},
+		MaxAge:         600,
+	})
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	swaggoMux.HandleFunc("/test", handler, "v1", swaggo.RequestDetails{Method: "GET"}, swaggo.RequestDetails{
+		Method: "PUT",
+		CorsConfiguration: &swaggo.CorsConfiguration{
+			AllowedOrigins:   []string{"https://admin.example.com"},
+			AllowCredentials: true,
+		},
+	})
+
+	request := httptest.NewRequest(http.MethodOptions, "/api/v1/test", nil)
+	request.Header.Set("Origin", "https://admin.example.com")
+	request.Header.Set("Access-Control-Request-Method", "PUT")
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Header().Get("Access-Control-Allow-Origin") != "https://admin.example.com" {
+		t.Errorf("Expected admin origin to be allowed, got %s", recorder.Header().Get("Access-Control-Allow-Origin"))
+	}
+
+	if recorder.Header().Get("Access-Control-Allow-Credentials") != "true" {
+		t.Errorf("Expected credentials to be allowed")
+	}
+
+	request = httptest.NewRequest(http.MethodOptions, "/api/v1/test", nil)
+	request.Header.Set("Origin", "https://example.com")
+	request.Header.Set("Access-Control-Request-Method", "PUT")
+	recorder = httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("Expected mux origin to be rejected for PUT, got %s", recorder.Header().Get("Access-Control-Allow-Origin"))
+	}
+}
+
+func TestCorsActualRequestExposesDocumentedHeaders(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{"v1"})
+
+	swaggoMux.ConfigureCors(&swaggo.CorsConfiguration{
+		AllowedOrigins: []string{"https://example.com"},
+		AllowedHeaders: []string{"Content-Type"},
+		MaxAge:         600,
+	})
+
+	swaggoMux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Total-Count", "1")
+		w.WriteHeader(http.StatusOK)
+	}, "v1", swaggo.RequestDetails{
+		Method: "GET",
+		Responses: []swaggo.ResponseData{
+			{Code: 200, Data: TestChildrenModel{}, Headers: map[string]any{"X-Total-Count": 1}},
+		},
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/api/v1/test", nil)
+	request.Header.Set("Origin", "https://example.com")
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected 200, got %d", recorder.Code)
+	}
+
+	if recorder.Header().Get("Access-Control-Allow-Origin") != "https://example.com" {
+		t.Errorf("Expected origin to be allowed, got %s", recorder.Header().Get("Access-Control-Allow-Origin"))
+	}
+
+	if recorder.Header().Get("Access-Control-Expose-Headers") != "X-Total-Count" {
+		t.Errorf("Expected X-Total-Count to be exposed, got %s", recorder.Header().Get("Access-Control-Expose-Headers"))
+	}
+}
+
+func TestCorsVersionConfiguration(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{"v2"})
+
+	swaggoMux.ConfigureVersion("v2", swaggo.VersionConfiguration{
+		CorsConfiguration: &swaggo.CorsConfiguration{
+			AllowedOrigins: []string{"*"},
+		},
+	})
+
+	swaggoMux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}, "v2", swaggo.RequestDetails{Method: "GET"})
+
+	request := httptest.NewRequest(http.MethodGet, "/api/v2/test", nil)
+	request.Header.Set("Origin", "https://anywhere.com")
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Header().Get("Access-Control-Allow-Origin") != "*" {
+		t.Errorf("Expected wildcard origin, got %s", recorder.Header().Get("Access-Control-Allow-Origin"))
+	}
+}
@@ -0,0 +1,154 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Pieeer1/Auto-SwagGo/swaggo"
+)
+
+type DeprecationTestQuery struct {
+	Page   int `name:"page"`
+	Offset int `name:"offset" deprecated:"true"`
+}
+
+type DeprecationTestBody struct {
+	Name     string `json:"name"`
+	Nickname string `json:"nickname" deprecated:"true"`
+}
+
+var deprecationTestSunset = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+var deprecationTestDate = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestDeprecationIsDocumented(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{"v1", "v2"})
+
+	swaggoMux.ConfigureVersion("v1", swaggo.VersionConfiguration{
+		DeprecatedAt: deprecationTestDate,
+	})
+
+	swaggoMux.HandleFunc("/users", nil, "v1", swaggo.RequestDetails{Method: "GET"})
+
+	swaggoMux.HandleFunc("/users", nil, "v2", swaggo.RequestDetails{
+		Method:       "GET",
+		Deprecated:   true,
+		DeprecatedAt: deprecationTestDate,
+		Requests:     []swaggo.RequestData{{Type: swaggo.QuerySource, Data: DeprecationTestQuery{}}},
+	}, swaggo.RequestDetails{
+		Method:   "POST",
+		Requests: []swaggo.RequestData{{Type: swaggo.BodySource, Data: DeprecationTestBody{}}},
+	})
+
+	doc, err := swaggoMux.MapDoc("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !doc.Paths["/api/v1/users"]["get"].Deprecated {
+		t.Errorf("Expected operations of a deprecated version to be deprecated")
+	}
+
+	if !doc.Paths["/api/v2/users"]["get"].Deprecated || doc.Paths["/api/v2/users"]["post"].Deprecated {
+		t.Errorf("Expected only the deprecated operation to be deprecated")
+	}
+
+	parameters := doc.Paths["/api/v2/users"]["get"].Parameters
+
+	if parameters[0].Deprecated || !parameters[1].Deprecated {
+		t.Errorf("Expected only offset to be deprecated, got %+v", parameters)
+	}
+
+	properties := doc.Components.Schemas["DeprecationTestBody"].Properties
+
+	if properties["name"].Deprecated || !properties["nickname"].Deprecated {
+		t.Errorf("Expected only nickname to be deprecated, got %+v", properties)
+	}
+}
+
+func TestDeprecationHeaders(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{"v1", "v2"})
+
+	swaggoMux.ConfigureVersion("v1", swaggo.VersionConfiguration{
+		DeprecatedAt: deprecationTestDate,
+		Sunset:       deprecationTestSunset,
+		CorsConfiguration: &swaggo.CorsConfiguration{
+			AllowedOrigins: []string{"*"},
+		},
+	})
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	swaggoMux.HandleFunc("/users", handler, "v1", swaggo.RequestDetails{Method: "GET"})
+	swaggoMux.HandleFunc("/orders", handler, "v1", swaggo.RequestDetails{Method: "GET", DeprecatedAt: deprecationTestDate.AddDate(0, 6, 0)})
+
+	swaggoMux.HandleFunc("/users", handler, "v2", swaggo.RequestDetails{Method: "POST"})
+
+	request := httptest.NewRequest(http.MethodGet, "/api/v1/users", nil)
+	request.Header.Set("Origin", "http://example.com")
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Header().Get("Deprecation") != "@1735689600" {
+		t.Errorf("Expected Deprecation header, got %s", recorder.Header().Get("Deprecation"))
+	}
+
+	if recorder.Header().Get("Sunset") != "Tue, 01 Jan 2030 00:00:00 GMT" {
+		t.Errorf("Expected Sunset header, got %s", recorder.Header().Get("Sunset"))
+	}
+
+	if recorder.Header().Get("Access-Control-Expose-Headers") != "Deprecation, Sunset" {
+		t.Errorf("Expected deprecation headers to be exposed, got %s", recorder.Header().Get("Access-Control-Expose-Headers"))
+	}
+
+	request = httptest.NewRequest(http.MethodGet, "/api/v1/orders", nil)
+	recorder = httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Header().Get("Deprecation") != "@1751328000" || recorder.Header().Get("Sunset") != "Tue, 01 Jan 2030 00:00:00 GMT" {
+		t.Errorf("Expected the operation's deprecation date and the version's sunset, got %v", recorder.Header())
+	}
+
+	request = httptest.NewRequest(http.MethodPost, "/api/v2/users", nil)
+	recorder = httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Header().Get("Deprecation") != "" || recorder.Header().Get("Sunset") != "" {
+		t.Errorf("Expected no deprecation headers, got %v", recorder.Header())
+	}
+}
+
+func TestDeprecatedWithoutDateFailsRegistration(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{"v1"})
+
+	cases := map[string]func(){
+		"operation": func() {
+			swaggoMux.HandleFunc("/users", nil, "v1", swaggo.RequestDetails{Method: "GET", Deprecated: true})
+		},
+		"version": func() {
+			swaggoMux.ConfigureVersion("v1", swaggo.VersionConfiguration{Deprecated: true})
+		},
+	}
+
+	for name, register := range cases {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected a deprecated %s without a date to panic", name)
+				}
+			}()
+			register()
+		}()
+	}
+}
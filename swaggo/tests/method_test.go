@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Pieeer1/Auto-SwagGo/swaggo"
+)
+
+func TestDisallowedMethodShortCircuits(t *testing.T) {
+	handledMethods := []string{}
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		handledMethods = append(handledMethods, r.Method)
+		w.WriteHeader(http.StatusOK)
+	}, "", swaggo.RequestDetails{Method: "GET"}, swaggo.RequestDetails{Method: "POST"})
+
+	request := httptest.NewRequest(http.MethodDelete, "/api/test", nil)
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405, got %d", recorder.Code)
+	}
+
+	if recorder.Header().Get("Allow") != "GET, POST, OPTIONS" {
+		t.Errorf("Expected GET, POST, OPTIONS, got %s", recorder.Header().Get("Allow"))
+	}
+
+	if len(handledMethods) != 0 {
+		t.Errorf("Expected handler not to be called, got %v", handledMethods)
+	}
+}
+
+func TestOptionsIsAnsweredAutomatically(t *testing.T) {
+	handledMethods := []string{}
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		handledMethods = append(handledMethods, r.Method)
+		w.WriteHeader(http.StatusOK)
+	}, "", swaggo.RequestDetails{Method: "GET"})
+
+	request := httptest.NewRequest(http.MethodOptions, "/api/test", nil)
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusNoContent {
+		t.Errorf("Expected 204, got %d", recorder.Code)
+	}
+
+	if recorder.Header().Get("Allow") != "GET, OPTIONS" {
+		t.Errorf("Expected GET, OPTIONS, got %s", recorder.Header().Get("Allow"))
+	}
+
+	if len(handledMethods) != 0 {
+		t.Errorf("Expected handler not to be called, got %v", handledMethods)
+	}
+}
+
+func TestExplicitOptionsReachesHandler(t *testing.T) {
+	handledMethods := []string{}
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		handledMethods = append(handledMethods, r.Method)
+		w.WriteHeader(http.StatusOK)
+	}, "", swaggo.RequestDetails{Method: "GET"}, swaggo.RequestDetails{Method: "OPTIONS"})
+
+	request := httptest.NewRequest(http.MethodOptions, "/api/test", nil)
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected 200, got %d", recorder.Code)
+	}
+
+	if len(handledMethods) != 1 || handledMethods[0] != http.MethodOptions {
+		t.Errorf("Expected handler to be called for OPTIONS, got %v", handledMethods)
+	}
+}
@@ -0,0 +1,333 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Pieeer1/Auto-SwagGo/swaggo"
+)
+
+type NamingTestBody struct {
+	ExampleField string `json:"example_field,omitempty"`
+	NamedField   string `name:"named"`
+	UserID       int
+	Ignored      string `json:"-"`
+}
+
+type NamingTestQuery struct {
+	PageSize int    `json:"page_size"`
+	SortBy   string `json:"sort" name:"order"`
+	UserID   int
+}
+
+func TestNamingStrategies(t *testing.T) {
+	cases := map[string][2]string{
+		"UserID":     {"userId", "user_id"},
+		"HTTPServer": {"httpServer", "http_server"},
+		"Name":       {"name", "name"},
+		"PageSize2":  {"pageSize2", "page_size2"},
+	}
+
+	for fieldName, expected := range cases {
+		if camel := swaggo.CamelCaseStrategy(fieldName); camel != expected[0] {
+			t.Errorf("Expected %s, got %s", expected[0], camel)
+		}
+		if snake := swaggo.SnakeCaseStrategy(fieldName); snake != expected[1] {
+			t.Errorf("Expected %s, got %s", expected[1], snake)
+		}
+	}
+}
+
+func TestJsonTagsNameProperties(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.ConfigureNamingStrategy(swaggo.SnakeCaseStrategy)
+
+	swaggoMux.HandleFunc("/test", nil, "", swaggo.RequestDetails{
+		Method: "POST",
+		Requests: []swaggo.RequestData{
+			{Type: swaggo.QuerySource, Data: NamingTestQuery{}},
+			{Type: swaggo.BodySource, Data: NamingTestBody{}},
+		},
+	})
+
+	doc, err := swaggoMux.MapDoc("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	properties := doc.Components.Schemas["NamingTestBody"].Properties
+
+	for _, name := range []string{"example_field", "NamedField", "UserID"} {
+		if _, ok := properties[name]; !ok {
+			t.Errorf("Expected property %s, got %v", name, properties)
+		}
+	}
+
+	if len(properties) != 3 {
+		t.Errorf("Expected json:\"-\" field to be skipped, got %v", properties)
+	}
+
+	parameters := doc.Paths["/api/test"]["post"].Parameters
+
+	if parameters[0].Name != "page_size" || parameters[1].Name != "order" || parameters[2].Name != "user_id" {
+		t.Errorf("Expected page_size, order and user_id parameters, got %v", parameters)
+	}
+}
+
+func TestDocumentedBodyPropertiesAreAccepted(t *testing.T) {
+	var body NamingTestBody
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.ConfigureNamingStrategy(swaggo.SnakeCaseStrategy)
+
+	swaggoMux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}, "", swaggo.RequestDetails{
+		Method:   "POST",
+		Requests: []swaggo.RequestData{{Type: swaggo.BodySource, Data: NamingTestBody{}}},
+	})
+
+	doc, err := swaggoMux.MapDoc("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	documented := make(map[string]any)
+
+	for name := range doc.Components.Schemas["NamingTestBody"].Properties {
+		documented[name] = "a"
+	}
+	documented["UserID"] = 3
+
+	payload, err := json.Marshal(documented)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request := httptest.NewRequest(http.MethodPost, "/api/test", strings.NewReader(string(payload)))
+	request.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected the documented body %s to be accepted, got %d: %s", payload, recorder.Code, recorder.Body.String())
+	}
+
+	if body.ExampleField != "a" || body.NamedField != "a" || body.UserID != 3 {
+		t.Errorf("Expected the documented body to be decoded, got %+v", body)
+	}
+}
+
+func TestNamingStrategyAppliesToBinding(t *testing.T) {
+	var query NamingTestQuery
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.ConfigureNamingStrategy(swaggo.SnakeCaseStrategy)
+
+	swaggoMux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		query, _ = swaggo.BindQuery[NamingTestQuery](r)
+		w.WriteHeader(http.StatusOK)
+	}, "", swaggo.RequestDetails{
+		Method:   "POST",
+		Requests: []swaggo.RequestData{{Type: swaggo.QuerySource, Data: NamingTestQuery{}}},
+	})
+
+	request := httptest.NewRequest(http.MethodPost, "/api/test?page_size=10&order=name&user_id=3", nil)
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected 200, got %d", recorder.Code)
+	}
+
+	if query.PageSize != 10 || query.SortBy != "name" || query.UserID != 3 {
+		t.Errorf("Expected bound query, got %+v", query)
+	}
+}
+
+type NamingTestInvoice struct {
+	Total float64
+}
+
+type NamingTestNamedInvoice struct {
+	Total float64
+}
+
+func (NamingTestNamedInvoice) SchemaName() string {
+	return "LedgerInvoice"
+}
+
+// shadowed by the function scoped NamingTestInvoice types below
+var namingTestPackageInvoice any = NamingTestInvoice{}
+
+func TestSchemaNameCollisionFails(t *testing.T) {
+	type NamingTestInvoice struct {
+		Amount int
+	}
+
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	for i, invoice := range []any{NamingTestInvoice{}, namingTestPackageInvoice} {
+		swaggoMux.HandleFunc(fmt.Sprintf("/invoices%d", i), nil, "", swaggo.RequestDetails{
+			Method:    "GET",
+			Responses: []swaggo.ResponseData{{Code: 200, Data: invoice}},
+		})
+	}
+
+	_, err := swaggoMux.MapDoc("")
+
+	if err == nil || !strings.Contains(err.Error(), "schema name NamingTestInvoice is used by both") {
+		t.Errorf("Expected collision error, got %v", err)
+	}
+}
+
+func TestSchemaNameAliasAndSchemaNamer(t *testing.T) {
+	type NamingTestInvoice struct {
+		Amount int
+	}
+
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	for i, invoice := range []any{NamingTestInvoice{}, namingTestPackageInvoice, NamingTestNamedInvoice{}} {
+		swaggoMux.HandleFunc(fmt.Sprintf("/invoices%d", i), nil, "", swaggo.RequestDetails{
+			Method:    "GET",
+			Responses: []swaggo.ResponseData{{Code: 200, Data: invoice}},
+		})
+	}
+	swaggoMux.RegisterSchemaAlias(NamingTestInvoice{}, "LocalInvoice")
+
+	doc, err := swaggoMux.MapDoc("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"LocalInvoice", "NamingTestInvoice", "LedgerInvoice"} {
+		if _, ok := doc.Components.Schemas[name]; !ok {
+			t.Errorf("Expected %s schema, got %v", name, doc.Components.Schemas)
+		}
+	}
+
+	if doc.Paths["/api/invoices0"]["get"].Responses["200"].Content["application/json"].Schema.Ref != "#/components/schemas/LocalInvoice" {
+		t.Errorf("Expected alias reference, got %+v", doc.Paths["/api/invoices0"]["get"].Responses["200"])
+	}
+}
+
+func TestPackageQualifiedSchemaNames(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.HandleFunc("/invoices", nil, "", swaggo.RequestDetails{
+		Method:    "GET",
+		Responses: []swaggo.ResponseData{{Code: 200, Data: NamingTestInvoice{}}},
+	})
+	swaggoMux.ConfigureSchemaNaming(swaggo.PackageQualifiedSchemaName)
+
+	doc, err := swaggoMux.MapDoc("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := doc.Components.Schemas["tests.NamingTestInvoice"]; !ok {
+		t.Errorf("Expected tests.NamingTestInvoice schema, got %v", doc.Components.Schemas)
+	}
+}
+
+type NamingTestPage[T any] struct {
+	Items []T
+	Total int
+}
+
+type NamingTestPair[A any, B any] struct {
+	First  A
+	Second B
+}
+
+func TestGenericSchemaNames(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	for i, invoice := range []any{
+		NamingTestPage[NamingTestInvoice]{},
+		NamingTestPair[NamingTestInvoice, []int]{},
+		NamingTestPage[map[string]*NamingTestInvoice]{},
+		NamingTestPage[NamingTestPage[NamingTestInvoice]]{},
+	} {
+		swaggoMux.HandleFunc(fmt.Sprintf("/invoices%d", i), nil, "", swaggo.RequestDetails{
+			Method:    "GET",
+			Responses: []swaggo.ResponseData{{Code: 200, Data: invoice}},
+		})
+	}
+
+	doc, err := swaggoMux.MapDoc("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{
+		"NamingTestPageOfNamingTestInvoice",
+		"NamingTestPairOfNamingTestInvoiceAndIntArray",
+		"NamingTestPageOfStringToNamingTestInvoiceMap",
+		"NamingTestPageOfNamingTestPageOfNamingTestInvoice",
+	} {
+		if _, ok := doc.Components.Schemas[name]; !ok {
+			t.Errorf("Expected %s schema, got %v", name, doc.Components.Schemas)
+		}
+	}
+
+	ref := doc.Paths["/api/invoices0"]["get"].Responses["200"].Content["application/json"].Schema.Ref
+
+	if ref != "#/components/schemas/NamingTestPageOfNamingTestInvoice" {
+		t.Errorf("Expected generic reference, got %s", ref)
+	}
+}
+
+func TestGenericSchemaNamingHook(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.HandleFunc("/invoices", nil, "", swaggo.RequestDetails{
+		Method:    "GET",
+		Responses: []swaggo.ResponseData{{Code: 200, Data: NamingTestPage[NamingTestInvoice]{}}},
+	})
+	swaggoMux.ConfigureGenericSchemaNaming(func(name string, typeArguments []string) string {
+		return strings.Join(append(typeArguments, name), "")
+	})
+
+	doc, err := swaggoMux.MapDoc("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := doc.Components.Schemas["NamingTestInvoiceNamingTestPage"]; !ok {
+		t.Errorf("Expected NamingTestInvoiceNamingTestPage schema, got %v", doc.Components.Schemas)
+	}
+}
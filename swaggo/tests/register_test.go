@@ -0,0 +1,277 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Pieeer1/Auto-SwagGo/swaggo"
+)
+
+type RegisterTestUser struct {
+	Name  string `json:"name" required:"true" minLength:"1"`
+	Email string `json:"email"`
+}
+
+type RegisterTestCreateUser struct {
+	TenantId  int              `name:"tenantId" in:"path" required:"true"`
+	RequestId string           `name:"X-Request-Id" in:"header"`
+	User      RegisterTestUser `in:"body"`
+}
+
+type RegisterTestCreatedUser struct {
+	Id       int    `json:"id"`
+	TenantId int    `json:"tenantId"`
+	Name     string `json:"name"`
+}
+
+type RegisterTestGetUser struct {
+	TenantId int `name:"tenantId" in:"path"`
+}
+
+func TestRegisterDerivesDocumentation(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggo.Register(swaggoMux, http.MethodPost, "/tenants/{tenantId}/users", "", func(ctx context.Context, req RegisterTestCreateUser) (RegisterTestCreatedUser, error) {
+		if req.User.Name == "taken" {
+			return RegisterTestCreatedUser{}, swaggo.NewHttpError(http.StatusConflict, "user already exists")
+		}
+		return RegisterTestCreatedUser{Id: 1, TenantId: req.TenantId, Name: req.User.Name}, nil
+	}, swaggo.RequestDetails{
+		Summary: "Create a user",
+		Requests: []swaggo.RequestData{
+			{Type: swaggo.BodySource, Description: "The user to create", Examples: map[string]swaggo.Example{
+				"minimal": {Summary: "Only the required fields", Value: RegisterTestUser{Name: "Ada"}},
+			}},
+		},
+		Responses: []swaggo.ResponseData{
+			{Code: http.StatusCreated, Examples: map[string]swaggo.Example{
+				"created": {Value: RegisterTestCreatedUser{Id: 1, TenantId: 2, Name: "Ada"}},
+			}},
+			{Code: http.StatusConflict},
+		},
+	})
+
+	swaggo.Register(swaggoMux, http.MethodGet, "/tenants/{tenantId}/users", "", func(ctx context.Context, req RegisterTestGetUser) ([]RegisterTestCreatedUser, error) {
+		return []RegisterTestCreatedUser{{Id: 1, TenantId: req.TenantId}}, nil
+	})
+
+	swaggo.Register(swaggoMux, http.MethodDelete, "/users", "", func(ctx context.Context, req struct{}) (struct{}, error) {
+		return struct{}{}, nil
+	})
+
+	doc, err := swaggoMux.MapDoc("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	post := doc.Paths["/api/tenants/{tenantId}/users"]["post"]
+
+	if post.Summary != "Create a user" {
+		t.Errorf("Expected summary to be kept, got %s", post.Summary)
+	}
+
+	if len(post.Parameters) != 2 {
+		t.Fatalf("Expected path and header parameters, got %v", post.Parameters)
+	}
+
+	if post.Parameters[0].Name != "tenantId" || post.Parameters[0].In != "path" {
+		t.Errorf("Expected tenantId path parameter, got %v", post.Parameters[0])
+	}
+
+	if post.Parameters[1].Name != "X-Request-Id" || post.Parameters[1].In != "header" {
+		t.Errorf("Expected X-Request-Id header parameter, got %v", post.Parameters[1])
+	}
+
+	if post.RequestBody == nil || post.RequestBody.Content["application/json"].Schema.Ref != "#/components/schemas/RegisterTestUser" {
+		t.Errorf("Expected RegisterTestUser request body, got %v", post.RequestBody)
+	}
+
+	if post.Responses["201"].Content["application/json"].Schema.Ref != "#/components/schemas/RegisterTestCreatedUser" {
+		t.Errorf("Expected RegisterTestCreatedUser 201 response, got %v", post.Responses["201"])
+	}
+
+	if post.RequestBody.Description != "The user to create" || post.RequestBody.Content["application/json"].Examples["minimal"].Summary != "Only the required fields" {
+		t.Errorf("Expected the template body to describe the bound body, got %+v", post.RequestBody)
+	}
+
+	if _, ok := post.Responses["201"].Content["application/json"].Examples["created"]; !ok {
+		t.Errorf("Expected created example, got %v", post.Responses["201"].Content["application/json"].Examples)
+	}
+
+	if post.Responses["409"].Content["application/json"].Schema.Ref != "#/components/schemas/ErrorResponse" {
+		t.Errorf("Expected ErrorResponse 409 response, got %v", post.Responses["409"])
+	}
+
+	if post.Responses["422"].Content["application/json"].Schema.Ref != "#/components/schemas/ValidationErrorResponse" {
+		t.Errorf("Expected ValidationErrorResponse 422 response, got %v", post.Responses["422"])
+	}
+
+	get := doc.Paths["/api/tenants/{tenantId}/users"]["get"]
+
+	if len(get.Parameters) != 1 || !get.Parameters[0].Required {
+		t.Errorf("Expected tenantId path parameter to be required without a required tag, got %v", get.Parameters)
+	}
+
+	if get.Responses["200"].Content["application/json"].Schema.Type != "array" {
+		t.Errorf("Expected array 200 response, got %v", get.Responses["200"])
+	}
+
+	del := doc.Paths["/api/users"]["delete"]
+
+	if _, ok := del.Responses["204"]; !ok {
+		t.Errorf("Expected 204 response, got %v", del.Responses)
+	}
+
+	if _, ok := del.Responses["422"]; ok {
+		t.Errorf("Expected no 422 response without request data")
+	}
+}
+
+func TestRegisterDecodesAndEncodes(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggo.Register(swaggoMux, http.MethodPost, "/tenants/{tenantId}/users", "", func(ctx context.Context, req RegisterTestCreateUser) (RegisterTestCreatedUser, error) {
+		if req.User.Name == "taken" {
+			return RegisterTestCreatedUser{}, swaggo.NewHttpError(http.StatusConflict, "user already exists")
+		}
+		return RegisterTestCreatedUser{Id: 1, TenantId: req.TenantId, Name: req.User.Name}, nil
+	}, swaggo.RequestDetails{
+		Summary: "Create a user",
+		Requests: []swaggo.RequestData{
+			{Type: swaggo.BodySource, Description: "The user to create", Examples: map[string]swaggo.Example{
+				"minimal": {Summary: "Only the required fields", Value: RegisterTestUser{Name: "Ada"}},
+			}},
+		},
+		Responses: []swaggo.ResponseData{
+			{Code: http.StatusCreated, Examples: map[string]swaggo.Example{
+				"created": {Value: RegisterTestCreatedUser{Id: 1, TenantId: 2, Name: "Ada"}},
+			}},
+			{Code: http.StatusConflict},
+		},
+	})
+
+	swaggo.Register(swaggoMux, http.MethodGet, "/tenants/{tenantId}/users", "", func(ctx context.Context, req RegisterTestGetUser) ([]RegisterTestCreatedUser, error) {
+		return []RegisterTestCreatedUser{{Id: 1, TenantId: req.TenantId}}, nil
+	})
+
+	swaggo.Register(swaggoMux, http.MethodDelete, "/users", "", func(ctx context.Context, req struct{}) (struct{}, error) {
+		return struct{}{}, nil
+	})
+
+	request := httptest.NewRequest(http.MethodPost, "/api/tenants/7/users", strings.NewReader(`{"name": "gopher"}`))
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusCreated {
+		t.Errorf("Expected 201, got %d", recorder.Code)
+	}
+
+	if strings.TrimSpace(recorder.Body.String()) != `{"id":1,"tenantId":7,"name":"gopher"}` {
+		t.Errorf("Expected created user, got %s", recorder.Body.String())
+	}
+
+	request = httptest.NewRequest(http.MethodGet, "/api/tenants/7/users", nil)
+	recorder = httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected 200, got %d", recorder.Code)
+	}
+
+	request = httptest.NewRequest(http.MethodDelete, "/api/users", nil)
+	recorder = httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusNoContent || recorder.Body.Len() != 0 {
+		t.Errorf("Expected empty 204, got %d %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestRegisterValidatesInput(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggo.Register(swaggoMux, http.MethodPost, "/tenants/{tenantId}/users", "", func(ctx context.Context, req RegisterTestCreateUser) (RegisterTestCreatedUser, error) {
+		return RegisterTestCreatedUser{Id: 1, TenantId: req.TenantId, Name: req.User.Name}, nil
+	})
+
+	request := httptest.NewRequest(http.MethodPost, "/api/tenants/abc/users", strings.NewReader(`{"name": ""}`))
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422, got %d", recorder.Code)
+	}
+}
+
+func TestRegisterMapsTypedErrors(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggo.Register(swaggoMux, http.MethodPost, "/tenants/{tenantId}/users", "", func(ctx context.Context, req RegisterTestCreateUser) (RegisterTestCreatedUser, error) {
+		return RegisterTestCreatedUser{}, swaggo.NewHttpError(http.StatusConflict, "user already exists")
+	})
+
+	request := httptest.NewRequest(http.MethodPost, "/api/tenants/7/users", strings.NewReader(`{"name": "taken"}`))
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusConflict {
+		t.Errorf("Expected 409, got %d", recorder.Code)
+	}
+
+	if strings.TrimSpace(recorder.Body.String()) != `{"message":"user already exists"}` {
+		t.Errorf("Expected error message, got %s", recorder.Body.String())
+	}
+}
+
+type RegisterTestPage struct {
+	Page int `name:"page" in:"query"`
+}
+
+type RegisterTestListUsers struct {
+	RegisterTestPage
+	TenantId int `name:"tenantId" in:"path" required:"true"`
+}
+
+func TestRegisterRejectsInvalidRequests(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	cases := map[string]func(){
+		"embedded parameters": func() {
+			swaggo.Register(swaggoMux, http.MethodGet, "/tenants/{tenantId}/users", "", func(ctx context.Context, req RegisterTestListUsers) (struct{}, error) {
+				return struct{}{}, nil
+			})
+		},
+		"second body": func() {
+			swaggo.Register(swaggoMux, http.MethodPost, "/users", "", func(ctx context.Context, req RegisterTestUser) (struct{}, error) {
+				return struct{}{}, nil
+			}, swaggo.RequestDetails{
+				Requests: []swaggo.RequestData{{Type: swaggo.BodySource, Data: RegisterTestUser{}}},
+			})
+		},
+	}
+
+	for name, register := range cases {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected %s to panic on registration", name)
+				}
+			}()
+			register()
+		}()
+	}
+}
@@ -0,0 +1,157 @@
+package tests
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Pieeer1/Auto-SwagGo/swaggo"
+)
+
+type RegistryTestWebhookEvent struct {
+	Id      string              `json:"id"`
+	Payload RegistryTestPayload `json:"payload"`
+}
+
+type RegistryTestPayload struct {
+	Value int `json:"value"`
+}
+
+type RegistryTestCustom struct {
+	Value int `json:"value"`
+}
+
+type RegistryTestStatus string
+
+func (RegistryTestStatus) EnumValues() []any {
+	return []any{"on", "off"}
+}
+
+func TestRegisteredSchemasAreDocumented(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.HandleFunc("/invoices", nil, "", swaggo.RequestDetails{
+		Method:    "GET",
+		Responses: []swaggo.ResponseData{{Code: 200, Data: NamingTestInvoice{}}},
+	})
+	swaggoMux.RegisterSchema("WebhookEvent", RegistryTestWebhookEvent{})
+	swaggoMux.RegisterSchema("Status", RegistryTestStatus(""))
+	swaggoMux.RegisterSchema("Pet", swaggo.OneOf(UnionTestCat{}, UnionTestDog{}))
+
+	doc, err := swaggoMux.MapDoc("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"NamingTestInvoice", "WebhookEvent", "RegistryTestPayload", "Status", "Pet", "UnionTestCat", "UnionTestDog"} {
+		if _, ok := doc.Components.Schemas[name]; !ok {
+			t.Errorf("Expected %s schema, got %v", name, doc.Components.Schemas)
+		}
+	}
+
+	if _, ok := doc.Components.Schemas["RegistryTestWebhookEvent"]; ok {
+		t.Errorf("Expected the registered name to replace the type name")
+	}
+
+	if len(doc.Components.Schemas["Status"].Enum) != 2 {
+		t.Errorf("Expected status enum, got %+v", doc.Components.Schemas["Status"])
+	}
+
+	if len(doc.Components.Schemas["Pet"].OneOf) != 2 {
+		t.Errorf("Expected pet union, got %+v", doc.Components.Schemas["Pet"])
+	}
+}
+
+func TestSchemaRefLooksUpComponents(t *testing.T) {
+	registerUnionTestPet()
+
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+	swaggoMux.RegisterSchema("WebhookEvent", RegistryTestWebhookEvent{})
+	swaggoMux.RegisterSchema("Pet", swaggo.OneOf(UnionTestCat{}, UnionTestDog{}))
+
+	cases := map[string]any{
+		"#/components/schemas/WebhookEvent":        &RegistryTestWebhookEvent{},
+		"#/components/schemas/Pet":                 swaggo.OneOf(UnionTestCat{}, UnionTestDog{}),
+		"#/components/schemas/RegistryTestPayload": RegistryTestPayload{},
+		"#/components/schemas/UnionTestPet":        (*UnionTestPet)(nil),
+	}
+
+	for expected, data := range cases {
+		ref, err := swaggoMux.SchemaRef(data)
+
+		if err != nil || ref != expected {
+			t.Errorf("Expected %s, got %s: %v", expected, ref, err)
+		}
+	}
+
+	if _, err := swaggoMux.SchemaRef(swaggo.AnyOf(UnionTestCat{})); err == nil {
+		t.Errorf("Expected an unregistered union to have no component schema")
+	}
+
+	if _, err := swaggoMux.SchemaRef(0); err == nil || !strings.Contains(err.Error(), "int has no component schema") {
+		t.Errorf("Expected int to have no component schema, got %v", err)
+	}
+}
+
+func TestRegisteredSchemaNameCollisionFails(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.HandleFunc("/invoices", nil, "", swaggo.RequestDetails{
+		Method:    "GET",
+		Responses: []swaggo.ResponseData{{Code: 200, Data: NamingTestInvoice{}}},
+	})
+	swaggoMux.RegisterSchema("NamingTestInvoice", swaggo.OneOf(UnionTestCat{}, UnionTestDog{}))
+
+	if _, err := swaggoMux.MapDoc(""); err == nil {
+		t.Errorf("Expected a registered schema reusing a component name to fail mapping")
+	}
+
+	swaggo.RegisterTypeSchema(reflect.TypeOf(RegistryTestCustom{}), swaggo.Schema{Type: "integer"})
+
+	if _, err := swaggoMux.SchemaRef(RegistryTestCustom{}); err == nil {
+		t.Errorf("Expected a type with a custom schema to have no component schema")
+	}
+}
+
+func TestRegisterSchemaRejectsNilAndSecondNames(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	if err := swaggoMux.RegisterSchema("Nothing", nil); err == nil {
+		t.Errorf("Expected nil data to fail registration")
+	}
+
+	if _, err := swaggoMux.SchemaRef(nil); err == nil {
+		t.Errorf("Expected nil to have no component schema")
+	}
+
+	if err := swaggoMux.RegisterSchema("WebhookEvent", RegistryTestWebhookEvent{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := swaggoMux.RegisterSchema("WebhookEvent", &RegistryTestWebhookEvent{}); err != nil {
+		t.Errorf("Expected registering the same name again to succeed, got %v", err)
+	}
+
+	if err := swaggoMux.RegisterSchema("Event", RegistryTestWebhookEvent{}); err == nil {
+		t.Errorf("Expected registering the same type under a second name to fail")
+	}
+
+	swaggoMux.RegisterSchemaAlias(RegistryTestPayload{}, "Payload")
+
+	if err := swaggoMux.RegisterSchema("EventPayload", RegistryTestPayload{}); err == nil {
+		t.Errorf("Expected registering an aliased type under another name to fail")
+	}
+
+	if ref, err := swaggoMux.SchemaRef(RegistryTestWebhookEvent{}); err != nil || ref != "#/components/schemas/WebhookEvent" {
+		t.Errorf("Expected the first name to be kept, got %s: %v", ref, err)
+	}
+}
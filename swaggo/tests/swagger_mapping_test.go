@@ -1,7 +1,11 @@
 package tests
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -161,12 +165,16 @@ func TestBaseSwaggerMap(t *testing.T) {
 		t.Errorf("Expected integer, got %s", doc.Components.Schemas["TestChildrenModels"].Properties["ExampleInts"].Items.Type)
 	}
 
-	if doc.Components.Schemas["TestChildrenModels"].Properties["ExampleChildrenModel"].Type != "object" {
-		t.Errorf("Expected object, got %s", doc.Components.Schemas["TestChildrenModels"].Properties["ExampleInts"].Type)
+	if doc.Components.Schemas["TestChildrenModels"].Properties["ExampleChildrenModel"].Ref != "#/components/schemas/TestChildrenModel" {
+		t.Errorf("Expected TestChildrenModel ref, got %s", doc.Components.Schemas["TestChildrenModels"].Properties["ExampleChildrenModel"].Ref)
 	}
 
-	if doc.Components.Schemas["TestChildrenModels"].Properties["ExampleChildrenModel"].Properties["ExampleChildrenField"].Type != "string" {
-		t.Errorf("Expected string, got %s", doc.Components.Schemas["TestChildrenModels"].Properties["ExampleString"].Type)
+	if doc.Components.Schemas["TestChildrenModel"].Properties["ExampleChildrenField"].Type != "string" {
+		t.Errorf("Expected string, got %s", doc.Components.Schemas["TestChildrenModel"].Properties["ExampleChildrenField"].Type)
+	}
+
+	if doc.Components.Schemas["TestChildrenModels"].Properties["ExampleChildrenArrayModel"].Items.Ref != "#/components/schemas/TestChildrenArrayModel" {
+		t.Errorf("Expected TestChildrenArrayModel ref, got %s", doc.Components.Schemas["TestChildrenModels"].Properties["ExampleChildrenArrayModel"].Items.Ref)
 	}
 
 }
@@ -367,8 +375,8 @@ func TestSwaggerMappingVersioning(t *testing.T) {
 		t.Errorf("Expected Test, got %s", doc.Paths["/api/v2/test"]["get"].Summary)
 	}
 
-	if len(doc.Components.Schemas) != 2 {
-		t.Errorf("Expected 2 schemas, got %d", len(doc.Components.Schemas))
+	if len(doc.Components.Schemas) != 4 {
+		t.Errorf("Expected 4 schemas, got %d", len(doc.Components.Schemas))
 	}
 
 	docv1, err := swaggoMux.MapDoc("v1")
@@ -385,8 +393,8 @@ func TestSwaggerMappingVersioning(t *testing.T) {
 		t.Errorf("Expected Test, got %s", docv1.Paths["/api/v1/test"]["get"].Summary)
 	}
 
-	if len(docv1.Components.Schemas) != 2 {
-		t.Errorf("Expected 2 schemas, got %d", len(doc.Components.Schemas))
+	if len(docv1.Components.Schemas) != 4 {
+		t.Errorf("Expected 4 schemas, got %d", len(doc.Components.Schemas))
 	}
 
 	docv2, err := swaggoMux.MapDoc("v2")
@@ -408,3 +416,542 @@ func TestSwaggerMappingVersioning(t *testing.T) {
 	}
 
 }
+
+type TestTreeNode struct {
+	Name     string
+	Parent   *TestTreeNode
+	Children []TestTreeNode
+}
+
+func TestSwaggerMappingRecursiveType(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.HandleFunc("/test", nil, "", swaggo.RequestDetails{
+		Method: "GET",
+		Responses: []swaggo.ResponseData{
+			{Code: 200, Data: TestTreeNode{}},
+		},
+	})
+
+	doc, err := swaggoMux.MapDoc("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	properties := doc.Components.Schemas["TestTreeNode"].Properties
+
+	if !properties["Parent"].Nullable || properties["Parent"].AllOf[0].Ref != "#/components/schemas/TestTreeNode" {
+		t.Errorf("Expected nullable self reference, got %+v", properties["Parent"])
+	}
+
+	if properties["Children"].Items.Ref != "#/components/schemas/TestTreeNode" {
+		t.Errorf("Expected self reference, got %s", properties["Children"].Items.Ref)
+	}
+
+	if len(doc.Components.Schemas) != 1 {
+		t.Errorf("Expected 1 schema, got %d", len(doc.Components.Schemas))
+	}
+}
+
+type TestPrice struct {
+	Amount   float64
+	Currency string
+}
+
+type TestPriceList struct {
+	Prices  map[string]TestPrice
+	Counts  map[string]int
+	Buckets map[string][]int
+}
+
+func TestSwaggerMappingMaps(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.HandleFunc("/test", nil, "", swaggo.RequestDetails{
+		Method: "POST",
+		Requests: []swaggo.RequestData{
+			{Type: swaggo.BodySource, Data: map[string]TestPriceList{}},
+		},
+		Responses: []swaggo.ResponseData{
+			{Code: 200, Data: map[string]string{}, Headers: map[string]any{"X-Limits": map[string]int{}}},
+		},
+	})
+
+	doc, err := swaggoMux.MapDoc("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	properties := doc.Components.Schemas["TestPriceList"].Properties
+
+	if properties["Prices"].Type != "object" || properties["Prices"].AdditionalProperties.Ref != "#/components/schemas/TestPrice" {
+		t.Errorf("Expected TestPrice additional properties, got %+v", properties["Prices"])
+	}
+
+	if properties["Counts"].AdditionalProperties.Type != "integer" {
+		t.Errorf("Expected integer additional properties, got %+v", properties["Counts"].AdditionalProperties)
+	}
+
+	if properties["Buckets"].AdditionalProperties.Type != "array" || properties["Buckets"].AdditionalProperties.Items.Type != "integer" {
+		t.Errorf("Expected integer array additional properties, got %+v", properties["Buckets"].AdditionalProperties)
+	}
+
+	if _, ok := doc.Components.Schemas["TestPrice"]; !ok {
+		t.Errorf("Expected TestPrice schema to be registered")
+	}
+
+	post := doc.Paths["/api/test"]["post"]
+
+	if post.RequestBody.Content["application/json"].Schema.AdditionalProperties.Ref != "#/components/schemas/TestPriceList" {
+		t.Errorf("Expected TestPriceList additional properties, got %+v", post.RequestBody.Content["application/json"].Schema)
+	}
+
+	if post.Responses["200"].Content["application/json"].Schema.AdditionalProperties.Type != "string" {
+		t.Errorf("Expected string additional properties, got %+v", post.Responses["200"].Content["application/json"].Schema)
+	}
+
+	if post.Responses["200"].Headers["X-Limits"].Schema.AdditionalProperties.Type != "integer" {
+		t.Errorf("Expected integer additional properties, got %+v", post.Responses["200"].Headers["X-Limits"].Schema)
+	}
+}
+
+func TestSwaggerMappingContainerBodyNames(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.HandleFunc("/price", nil, "", swaggo.RequestDetails{
+		Method:   "POST",
+		Requests: []swaggo.RequestData{{Type: swaggo.BodySource, Data: TestPrice{}}},
+	}, swaggo.RequestDetails{
+		Method:   "PUT",
+		Requests: []swaggo.RequestData{{Type: swaggo.BodySource, Data: &TestPrice{}}},
+	})
+
+	swaggoMux.HandleFunc("/prices", nil, "", swaggo.RequestDetails{
+		Method:   "POST",
+		Requests: []swaggo.RequestData{{Type: swaggo.BodySource, Data: []TestPrice{}}},
+	}, swaggo.RequestDetails{
+		Method:   "PUT",
+		Requests: []swaggo.RequestData{{Type: swaggo.BodySource, Data: map[string]TestPrice{}}},
+	})
+
+	doc, err := swaggoMux.MapDoc("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, schemaType := range map[string]string{"TestPrice": "", "TestPriceArray": "array", "StringToTestPriceMap": "object"} {
+		body, ok := doc.Components.RequestBodies[name]
+
+		if !ok || body.Content["application/json"].Schema.Type != schemaType {
+			t.Errorf("Expected %s body of type %q, got %+v", name, schemaType, doc.Components.RequestBodies)
+		}
+	}
+
+	swaggoMux.HandleFunc("/nullable-prices", nil, "", swaggo.RequestDetails{
+		Method:   "POST",
+		Requests: []swaggo.RequestData{{Type: swaggo.BodySource, Data: []*TestPrice{}}},
+	})
+
+	if _, err := swaggoMux.MapDoc(""); err == nil {
+		t.Errorf("Expected two bodies named TestPriceArray to fail mapping")
+	}
+}
+
+type TestAuditFields struct {
+	CreatedBy string `required:"true"`
+	UpdatedBy string
+}
+
+type TestAuditedModel struct {
+	TestAuditFields
+	Name      string
+	UpdatedBy int
+}
+
+func TestSwaggerMappingFlattensEmbeddedStructs(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.ConfigureEmbeddedStructs(swaggo.FlattenEmbeddedStructs)
+
+	swaggoMux.HandleFunc("/test", nil, "", swaggo.RequestDetails{
+		Method: "GET",
+		Responses: []swaggo.ResponseData{
+			{Code: 200, Data: TestAuditedModel{}},
+		},
+	})
+
+	doc, err := swaggoMux.MapDoc("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schema := doc.Components.Schemas["TestAuditedModel"]
+
+	if len(schema.Properties) != 3 {
+		t.Errorf("Expected CreatedBy, UpdatedBy and Name, got %v", schema.Properties)
+	}
+
+	if schema.Properties["UpdatedBy"].Type != "integer" {
+		t.Errorf("Expected the declared field to win, got %s", schema.Properties["UpdatedBy"].Type)
+	}
+
+	if len(schema.Required) != 1 || schema.Required[0] != "CreatedBy" {
+		t.Errorf("Expected promoted required field, got %v", schema.Required)
+	}
+
+	if _, ok := doc.Components.Schemas["TestAuditFields"]; ok {
+		t.Errorf("Expected no TestAuditFields schema when flattening")
+	}
+}
+
+func TestSwaggerMappingComposesEmbeddedStructs(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.ConfigureEmbeddedStructs(swaggo.AllOfEmbeddedStructs)
+
+	swaggoMux.HandleFunc("/test", nil, "", swaggo.RequestDetails{
+		Method: "GET",
+		Responses: []swaggo.ResponseData{
+			{Code: 200, Data: TestAuditedModel{}},
+		},
+	})
+
+	doc, err := swaggoMux.MapDoc("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schema := doc.Components.Schemas["TestAuditedModel"]
+
+	if len(schema.AllOf) != 2 || schema.AllOf[0].Ref != "#/components/schemas/TestAuditFields" {
+		t.Fatalf("Expected allOf with TestAuditFields, got %+v", schema)
+	}
+
+	if len(schema.AllOf[1].Properties) != 2 {
+		t.Errorf("Expected Name and UpdatedBy, got %v", schema.AllOf[1].Properties)
+	}
+
+	if len(doc.Components.Schemas["TestAuditFields"].Properties) != 2 {
+		t.Errorf("Expected TestAuditFields schema, got %+v", doc.Components.Schemas["TestAuditFields"])
+	}
+}
+
+type TestShadowBase struct {
+	Id   int    `json:"id"`
+	Name string `json:"Name"`
+}
+
+type TestShadowUntagged struct {
+	Name bool
+}
+
+type TestShadowModel struct {
+	TestShadowBase
+	TestShadowUntagged
+	Id string `json:"id"`
+}
+
+func TestEmbeddedFieldDominance(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	handled := false
+
+	swaggoMux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		handled = true
+		w.WriteHeader(http.StatusOK)
+	}, "", swaggo.RequestDetails{
+		Method:   "POST",
+		Requests: []swaggo.RequestData{{Type: swaggo.BodySource, Data: TestShadowModel{}}},
+	})
+
+	doc, err := swaggoMux.MapDoc("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	properties := doc.Components.Schemas["TestShadowModel"].Properties
+
+	if len(properties) != 2 || properties["id"].Type != "string" || properties["Name"].Type != "string" {
+		t.Errorf("Expected the outer string id and the tagged string Name, got %+v", properties)
+	}
+
+	request := httptest.NewRequest(http.MethodPost, "/api/test", strings.NewReader(`{"id":"abc","Name":"n"}`))
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK || !handled {
+		t.Errorf("Expected the shadowing string id to be accepted, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	request = httptest.NewRequest(http.MethodPost, "/api/test", strings.NewReader(`{"id":1}`))
+	recorder = httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected the shadowed integer id to be rejected, got %d", recorder.Code)
+	}
+}
+
+type TestNumericModel struct {
+	Small    int8
+	Short    int16
+	Regular  int32
+	Large    int64
+	Byte     uint8
+	Port     uint16
+	Count    uint
+	Ratio    float32
+	Amount   float64
+	Bounded  uint8 `maximum:"100"`
+	Quantity *uint32
+}
+
+func TestSwaggerMappingNumericFormats(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.HandleFunc("/test", nil, "", swaggo.RequestDetails{
+		Method: "GET",
+		Requests: []swaggo.RequestData{
+			{Type: swaggo.QuerySource, Data: TestNumericModel{}},
+		},
+		Responses: []swaggo.ResponseData{
+			{Code: 200, Data: TestNumericModel{}},
+		},
+	})
+
+	doc, err := swaggoMux.MapDoc("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	properties := doc.Components.Schemas["TestNumericModel"].Properties
+
+	expectedFormats := map[string]string{
+		"Small": "int32", "Short": "int32", "Regular": "int32", "Large": "int64", "Byte": "int32",
+		"Port": "int32", "Count": "int64", "Ratio": "float", "Amount": "double", "Quantity": "int64",
+	}
+
+	for name, format := range expectedFormats {
+		if properties[name].Format != format {
+			t.Errorf("Expected %s format for %s, got %s", format, name, properties[name].Format)
+		}
+	}
+
+	if properties["Count"].Type != "integer" || *properties["Count"].Minimum != 0 || properties["Count"].Maximum != nil {
+		t.Errorf("Expected unsigned integer, got %+v", properties["Count"])
+	}
+
+	if *properties["Small"].Minimum != -128 || *properties["Small"].Maximum != 127 {
+		t.Errorf("Expected int8 bounds, got %+v", properties["Small"])
+	}
+
+	if *properties["Port"].Minimum != 0 || *properties["Port"].Maximum != 65535 {
+		t.Errorf("Expected uint16 bounds, got %+v", properties["Port"])
+	}
+
+	if *properties["Bounded"].Minimum != 0 || *properties["Bounded"].Maximum != 100 {
+		t.Errorf("Expected tag maximum to override implicit bound, got %+v", properties["Bounded"])
+	}
+
+	parameters := doc.Paths["/api/test"]["get"].Parameters
+
+	if parameters[4].Name != "Byte" || parameters[4].Schema.Type != "integer" || *parameters[4].Schema.Maximum != 255 {
+		t.Errorf("Expected uint8 parameter bounds, got %+v", parameters[4])
+	}
+}
+
+type TestNullableModel struct {
+	Name      string
+	Nickname  *string
+	DeletedAt *time.Time
+	Child     *TestChildrenModel
+	Notes     string `json:"notes,omitempty"`
+	Forced    *int   `required:"true"`
+}
+
+func TestSwaggerMappingNullablePointers(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.ConfigureOpenApiVersion(swaggo.OpenApi30)
+	swaggoMux.ConfigureRequiredInference(true)
+
+	swaggoMux.HandleFunc("/test", nil, "", swaggo.RequestDetails{
+		Method: "GET",
+		Requests: []swaggo.RequestData{
+			{Type: swaggo.QuerySource, Data: struct{ Limit *int }{}},
+		},
+		Responses: []swaggo.ResponseData{
+			{Code: 200, Data: TestNullableModel{}},
+		},
+	})
+
+	doc, err := swaggoMux.MapDoc("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if doc.OpenAPIVersion != "3.0.2" {
+		t.Errorf("Expected 3.0.2, got %s", doc.OpenAPIVersion)
+	}
+
+	schema := doc.Components.Schemas["TestNullableModel"]
+
+	if schema.Properties["Name"].Nullable || !schema.Properties["Nickname"].Nullable || !schema.Properties["DeletedAt"].Nullable {
+		t.Errorf("Expected only pointers to be nullable, got %+v", schema.Properties)
+	}
+
+	if schema.Properties["DeletedAt"].Format != "date-time" {
+		t.Errorf("Expected date-time, got %s", schema.Properties["DeletedAt"].Format)
+	}
+
+	if !schema.Properties["Child"].Nullable || schema.Properties["Child"].AllOf[0].Ref != "#/components/schemas/TestChildrenModel" {
+		t.Errorf("Expected nullable reference, got %+v", schema.Properties["Child"])
+	}
+
+	if fmt.Sprint(schema.Required) != "[Name Forced]" {
+		t.Errorf("Expected Name and Forced to be required, got %v", schema.Required)
+	}
+
+	if doc.Paths["/api/test"]["get"].Parameters[0].Schema.Nullable {
+		t.Errorf("Expected pointer parameters not to be nullable")
+	}
+}
+
+func TestSwaggerMappingNullablePointers31(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.ConfigureOpenApiVersion(swaggo.OpenApi31)
+
+	swaggoMux.HandleFunc("/test", nil, "", swaggo.RequestDetails{
+		Method: "GET",
+		Responses: []swaggo.ResponseData{
+			{Code: 200, Data: TestNullableModel{}},
+		},
+	})
+
+	doc, err := swaggoMux.MapDoc("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if doc.OpenAPIVersion != "3.1.0" {
+		t.Errorf("Expected 3.1.0, got %s", doc.OpenAPIVersion)
+	}
+
+	body, err := json.Marshal(doc.Components.Schemas["TestNullableModel"])
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var schema struct {
+		Properties map[string]map[string]any `json:"properties"`
+	}
+
+	if err := json.Unmarshal(body, &schema); err != nil {
+		t.Fatal(err)
+	}
+
+	if fmt.Sprint(schema.Properties["Nickname"]["type"]) != "[string null]" {
+		t.Errorf("Expected string and null types, got %v", schema.Properties["Nickname"]["type"])
+	}
+
+	if _, ok := schema.Properties["Nickname"]["nullable"]; ok {
+		t.Errorf("Expected no nullable keyword in 3.1")
+	}
+
+	if schema.Properties["Name"]["type"] != "string" {
+		t.Errorf("Expected string type, got %v", schema.Properties["Name"]["type"])
+	}
+
+	if len(schema.Properties["Child"]["anyOf"].([]any)) != 2 {
+		t.Errorf("Expected anyOf reference and null, got %v", schema.Properties["Child"])
+	}
+}
+
+func TestSwaggerMappingExamples(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.HandleFunc("/test", nil, "", swaggo.RequestDetails{
+		Method: "POST",
+		Requests: []swaggo.RequestData{
+			{Type: swaggo.BodySource, Data: TestChildrenModel{}, ContentType: []string{"application/json", "application/xml"}, Examples: map[string]swaggo.Example{
+				"typical": {Summary: "A typical child", Value: TestChildrenModel{ExampleChildrenField: "value"}},
+				"empty":   {Summary: "An empty child", Description: "Every field left out", Value: map[string]any{}},
+			}},
+		},
+		Responses: []swaggo.ResponseData{
+			{Code: 200, Data: TestChildrenArrayModel{}, Examples: map[string]swaggo.Example{
+				"hosted": {Summary: "A hosted payload", ExternalValue: "https://example.com/child.json"},
+			}},
+		},
+	})
+
+	doc, err := swaggoMux.MapDoc("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operation := doc.Paths["/api/test"]["post"]
+
+	for _, contentType := range []string{"application/json", "application/xml"} {
+		if len(operation.RequestBody.Content[contentType].Examples) != 2 {
+			t.Errorf("Expected 2 %s examples, got %v", contentType, operation.RequestBody.Content[contentType].Examples)
+		}
+	}
+
+	if operation.RequestBody.Content["application/json"].Examples["empty"].Description != "Every field left out" {
+		t.Errorf("Expected example description, got %+v", operation.RequestBody.Content["application/json"].Examples["empty"])
+	}
+
+	if operation.Responses["200"].Content["application/json"].Examples["hosted"].ExternalValue != "https://example.com/child.json" {
+		t.Errorf("Expected external example, got %v", operation.Responses["200"].Content["application/json"].Examples)
+	}
+
+	if len(doc.Components.RequestBodies["TestChildrenModel"].Content["application/json"].Examples) != 2 {
+		t.Errorf("Expected request body component examples, got %v", doc.Components.RequestBodies["TestChildrenModel"])
+	}
+
+	swaggoMux.HandleFunc("/invalid", nil, "", swaggo.RequestDetails{
+		Method: "GET",
+		Responses: []swaggo.ResponseData{
+			{Code: 200, Data: TestChildrenModel{}, Examples: map[string]swaggo.Example{
+				"both": {Value: TestChildrenModel{}, ExternalValue: "https://example.com/child.json"},
+			}},
+		},
+	})
+
+	if _, err := swaggoMux.MapDoc(""); err == nil {
+		t.Errorf("Expected an example with a value and an externalValue to fail mapping")
+	}
+}
@@ -0,0 +1,374 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Pieeer1/Auto-SwagGo/swaggo"
+)
+
+type TypeSchemaTestVersion struct {
+	Major int
+	Minor int
+}
+
+func (TypeSchemaTestVersion) OpenApiSchema() swaggo.Schema {
+	return swaggo.Schema{Type: "string", Pattern: `^\d+\.\d+$`}
+}
+
+type TypeSchemaTestMoney struct {
+	Units int64
+	Nanos int32
+}
+
+type TypeSchemaTestBody struct {
+	Raw      json.RawMessage       `json:"raw"`
+	Address  net.IP                `json:"address"`
+	Website  url.URL               `json:"website"`
+	Timeout  time.Duration         `json:"timeout"`
+	Balance  *big.Int              `json:"balance"`
+	Nickname sql.NullString        `json:"nickname"`
+	Score    sql.NullFloat64       `json:"score"`
+	Version  TypeSchemaTestVersion `json:"version"`
+	Price    TypeSchemaTestMoney   `json:"price"`
+}
+
+func TestTypeSchemasAreDocumented(t *testing.T) {
+	swaggo.RegisterTypeSchema(reflect.TypeOf(TypeSchemaTestMoney{}), swaggo.Schema{Type: "string", Format: "decimal"})
+
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.HandleFunc("/test", nil, "", swaggo.RequestDetails{
+		Method:   "POST",
+		Requests: []swaggo.RequestData{{Type: swaggo.BodySource, Data: TypeSchemaTestBody{}}},
+	})
+
+	doc, err := swaggoMux.MapDoc("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	properties := doc.Components.Schemas["TypeSchemaTestBody"].Properties
+
+	expected := map[string][2]string{
+		"raw":      {"", ""},
+		"address":  {"string", ""},
+		"website":  {"string", "uri"},
+		"timeout":  {"integer", "int64"},
+		"balance":  {"integer", ""},
+		"nickname": {"string", ""},
+		"score":    {"number", "double"},
+		"version":  {"string", ""},
+		"price":    {"string", "decimal"},
+	}
+
+	for name, typeAndFormat := range expected {
+		if properties[name].Type != typeAndFormat[0] || properties[name].Format != typeAndFormat[1] || properties[name].Ref != "" {
+			t.Errorf("Expected %s to be %v, got %+v", name, typeAndFormat, properties[name])
+		}
+	}
+
+	if !properties["nickname"].Nullable || !properties["balance"].Nullable || properties["address"].Nullable {
+		t.Errorf("Expected sql.NullString and *big.Int to be nullable, got %+v and %+v", properties["nickname"], properties["balance"])
+	}
+
+	if properties["version"].Pattern != `^\d+\.\d+$` {
+		t.Errorf("Expected provided pattern, got %s", properties["version"].Pattern)
+	}
+
+	for _, name := range []string{"TypeSchemaTestVersion", "TypeSchemaTestMoney", "URL", "Int"} {
+		if _, ok := doc.Components.Schemas[name]; ok {
+			t.Errorf("Expected %s not to be reflected into a component schema", name)
+		}
+	}
+}
+
+func TestTypeSchemasAreValidated(t *testing.T) {
+	swaggo.RegisterTypeSchema(reflect.TypeOf(TypeSchemaTestMoney{}), swaggo.Schema{Type: "string", Format: "decimal"})
+
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}, "", swaggo.RequestDetails{
+		Method:   "POST",
+		Requests: []swaggo.RequestData{{Type: swaggo.BodySource, Data: TypeSchemaTestBody{}}},
+	})
+
+	request := httptest.NewRequest(http.MethodPost, "/api/test", strings.NewReader(`{"raw":{"any":[1]},"address":"10.0.0.1","website":"https://example.com","timeout":1000,"balance":123456789012345678901234567890,"nickname":null,"version":"1.2","price":"1.50"}`))
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	request = httptest.NewRequest(http.MethodPost, "/api/test", strings.NewReader(`{"website":"not a url","timeout":"1s","balance":1.5,"version":{"Major":1}}`))
+	recorder = httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	var response swaggo.ValidationErrorResponse
+
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+
+	if recorder.Code != http.StatusUnprocessableEntity || len(response.Errors) != 4 {
+		t.Errorf("Expected 4 errors, got %d: %v", recorder.Code, response.Errors)
+	}
+}
+
+type TypeSchemaTestColor struct {
+	R, G, B uint8
+}
+
+func (c TypeSchemaTestColor) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)), nil
+}
+
+func (c *TypeSchemaTestColor) UnmarshalText(text []byte) error {
+	_, err := fmt.Sscanf(string(text), "#%02x%02x%02x", &c.R, &c.G, &c.B)
+	return err
+}
+
+type TypeSchemaTestPoint struct {
+	X, Y float64
+}
+
+func (p TypeSchemaTestPoint) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]float64{p.X, p.Y})
+}
+
+type TypeSchemaTestMarshalerBody struct {
+	Color    TypeSchemaTestColor   `json:"color"`
+	Palette  []TypeSchemaTestColor `json:"palette"`
+	Location TypeSchemaTestPoint   `json:"location"`
+}
+
+type TypeSchemaTestMarshalerQuery struct {
+	Color TypeSchemaTestColor `name:"color"`
+}
+
+func TestMarshalerTypesAreDocumented(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.HandleFunc("/test", nil, "", swaggo.RequestDetails{
+		Method: "POST",
+		Requests: []swaggo.RequestData{
+			{Type: swaggo.QuerySource, Data: TypeSchemaTestMarshalerQuery{}},
+			{Type: swaggo.BodySource, Data: TypeSchemaTestMarshalerBody{}},
+		},
+	})
+
+	doc, err := swaggoMux.MapDoc("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	properties := doc.Components.Schemas["TypeSchemaTestMarshalerBody"].Properties
+
+	if properties["color"].Type != "string" || properties["color"].Properties != nil {
+		t.Errorf("Expected text marshaler to be a string, got %+v", properties["color"])
+	}
+
+	if properties["palette"].Items.Type != "string" {
+		t.Errorf("Expected text marshaler items to be strings, got %+v", properties["palette"].Items)
+	}
+
+	if properties["location"].Type != "" || properties["location"].Ref != "" {
+		t.Errorf("Expected json marshaler to accept any value, got %+v", properties["location"])
+	}
+
+	if doc.Paths["/api/test"]["post"].Parameters[0].Schema.Type != "string" {
+		t.Errorf("Expected string parameter, got %+v", doc.Paths["/api/test"]["post"].Parameters[0].Schema)
+	}
+
+	for _, name := range []string{"TypeSchemaTestColor", "TypeSchemaTestPoint"} {
+		if _, ok := doc.Components.Schemas[name]; ok {
+			t.Errorf("Expected %s not to be reflected into a component schema", name)
+		}
+	}
+}
+
+func TestMarshalerTypesAreBoundAndValidated(t *testing.T) {
+	var query TypeSchemaTestMarshalerQuery
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		query, _ = swaggo.BindQuery[TypeSchemaTestMarshalerQuery](r)
+		w.WriteHeader(http.StatusOK)
+	}, "", swaggo.RequestDetails{
+		Method: "POST",
+		Requests: []swaggo.RequestData{
+			{Type: swaggo.QuerySource, Data: TypeSchemaTestMarshalerQuery{}},
+			{Type: swaggo.BodySource, Data: TypeSchemaTestMarshalerBody{}},
+		},
+	})
+
+	request := httptest.NewRequest(http.MethodPost, "/api/test?color=%23ff8000", strings.NewReader(`{"color":"#000000","palette":["#ffffff"],"location":[1,2]}`))
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	if query.Color != (TypeSchemaTestColor{R: 255, G: 128}) {
+		t.Errorf("Expected bound color, got %+v", query.Color)
+	}
+
+	request = httptest.NewRequest(http.MethodPost, "/api/test?color=orange", strings.NewReader(`{"color":{"R":1},"palette":[1]}`))
+	recorder = httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	var response swaggo.ValidationErrorResponse
+
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+
+	if recorder.Code != http.StatusUnprocessableEntity || len(response.Errors) != 3 {
+		t.Errorf("Expected 3 errors, got %d: %v", recorder.Code, response.Errors)
+	}
+}
+
+type TypeSchemaTestPointerPoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+func (p *TypeSchemaTestPointerPoint) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]float64{p.X, p.Y})
+}
+
+type TypeSchemaTestPointerBody struct {
+	Value   TypeSchemaTestPointerPoint  `json:"value"`
+	Pointer *TypeSchemaTestPointerPoint `json:"pointer"`
+}
+
+func TestPointerReceiverMarshalersOnlyApplyThroughPointers(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}, "", swaggo.RequestDetails{
+		Method:   "POST",
+		Requests: []swaggo.RequestData{{Type: swaggo.BodySource, Data: TypeSchemaTestPointerBody{}}},
+	})
+
+	doc, err := swaggoMux.MapDoc("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	properties := doc.Components.Schemas["TypeSchemaTestPointerBody"].Properties
+
+	if properties["value"].Ref != "#/components/schemas/TypeSchemaTestPointerPoint" || len(doc.Components.Schemas["TypeSchemaTestPointerPoint"].Properties) != 2 {
+		t.Errorf("Expected value field to be reflected, got %+v", properties["value"])
+	}
+
+	if properties["pointer"].Ref != "" || len(properties["pointer"].AllOf) != 1 || properties["pointer"].AllOf[0].Ref != "" {
+		t.Errorf("Expected pointer field to accept any value, got %+v", properties["pointer"])
+	}
+
+	request := httptest.NewRequest(http.MethodPost, "/api/test", strings.NewReader(`{"value":{"x":1,"y":2},"pointer":[1,2]}`))
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	request = httptest.NewRequest(http.MethodPost, "/api/test", strings.NewReader(`{"value":[1,2]}`))
+	recorder = httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+type TypeSchemaTestPrice struct {
+	Cents int64
+}
+
+func (p *TypeSchemaTestPrice) UnmarshalText(text []byte) error {
+	var units, cents int64
+	if _, err := fmt.Sscanf(string(text), "%d.%02d", &units, &cents); err != nil {
+		return err
+	}
+	p.Cents = units*100 + cents
+	return nil
+}
+
+func (p *TypeSchemaTestPrice) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%d.%02d", p.Cents/100, p.Cents%100)), nil
+}
+
+type TypeSchemaTestPriceBody struct {
+	Price TypeSchemaTestPrice `json:"price"`
+}
+
+func TestPointerReceiverTextValuesAreAccepted(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggo.Register(swaggoMux, http.MethodPost, "/prices", "", func(ctx context.Context, req TypeSchemaTestPriceBody) (TypeSchemaTestPriceBody, error) {
+		return req, nil
+	})
+
+	doc, err := swaggoMux.MapDoc("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if price := doc.Components.Schemas["TypeSchemaTestPriceBody"].Properties["price"]; price.Type != "string" {
+		t.Errorf("Expected price to be documented as a string, got %+v", price)
+	}
+
+	request := httptest.NewRequest(http.MethodPost, "/api/prices", strings.NewReader(`{"price":"1.00"}`))
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected the price to be accepted, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	if strings.TrimSpace(recorder.Body.String()) != `{"price":"1.00"}` {
+		t.Errorf("Expected the price to be written as text, got %s", recorder.Body.String())
+	}
+
+	request = httptest.NewRequest(http.MethodPost, "/api/prices", strings.NewReader(`{"price":{"Cents":100}}`))
+	recorder = httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
@@ -0,0 +1,158 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Pieeer1/Auto-SwagGo/swaggo"
+)
+
+type UnionTestCat struct {
+	Kind  string `json:"kind" required:"true"`
+	Lives int    `json:"lives"`
+}
+
+type UnionTestDog struct {
+	Kind  string `json:"kind" required:"true"`
+	Breed string `json:"breed"`
+}
+
+type UnionTestPet interface {
+	isPet()
+}
+
+func (UnionTestCat) isPet() {}
+func (UnionTestDog) isPet() {}
+
+type UnionTestOwner struct {
+	Name string         `json:"name"`
+	Pet  UnionTestPet   `json:"pet"`
+	Pets []UnionTestPet `json:"pets"`
+}
+
+// registerUnionTestPet registers the pet union for the tests that document or validate UnionTestPet, which no other test uses.
+func registerUnionTestPet() {
+	swaggo.RegisterUnion(reflect.TypeOf((*UnionTestPet)(nil)).Elem(), swaggo.OneOf(UnionTestCat{}, UnionTestDog{}).WithDiscriminator("kind", map[string]any{
+		"cat": UnionTestCat{},
+		"dog": UnionTestDog{},
+	}))
+}
+
+func TestUnionsAreDocumented(t *testing.T) {
+	registerUnionTestPet()
+
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.HandleFunc("/pets", nil, "", swaggo.RequestDetails{
+		Method:    "POST",
+		Requests:  []swaggo.RequestData{{Type: swaggo.BodySource, Data: swaggo.OneOf(UnionTestCat{}, UnionTestDog{})}},
+		Responses: []swaggo.ResponseData{{Code: 200, Data: swaggo.AnyOf(UnionTestCat{}, UnionTestDog{})}},
+	})
+
+	swaggoMux.HandleFunc("/owners", nil, "", swaggo.RequestDetails{
+		Method:   "POST",
+		Requests: []swaggo.RequestData{{Type: swaggo.BodySource, Data: UnionTestOwner{}}},
+	})
+
+	doc, err := swaggoMux.MapDoc("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operation := doc.Paths["/api/pets"]["post"]
+	requestSchema := operation.RequestBody.Content["application/json"].Schema
+
+	if len(requestSchema.OneOf) != 2 || requestSchema.OneOf[0].Ref != "#/components/schemas/UnionTestCat" || requestSchema.Discriminator != nil {
+		t.Errorf("Expected oneOf cat and dog, got %+v", requestSchema)
+	}
+
+	responseSchema := operation.Responses["200"].Content["application/json"].Schema
+
+	if len(responseSchema.AnyOf) != 2 || responseSchema.AnyOf[1].Ref != "#/components/schemas/UnionTestDog" {
+		t.Errorf("Expected anyOf cat and dog, got %+v", responseSchema)
+	}
+
+	if _, ok := doc.Components.RequestBodies["OneOfUnionTestCatAndUnionTestDog"]; !ok {
+		t.Errorf("Expected union request body, got %v", doc.Components.RequestBodies)
+	}
+
+	pet := doc.Components.Schemas["UnionTestPet"]
+
+	if len(pet.OneOf) != 2 || pet.Discriminator == nil || pet.Discriminator.PropertyName != "kind" || pet.Discriminator.Mapping["dog"] != "#/components/schemas/UnionTestDog" {
+		t.Errorf("Expected discriminated pet union, got %+v", pet)
+	}
+
+	properties := doc.Components.Schemas["UnionTestOwner"].Properties
+
+	if properties["pet"].Ref != "#/components/schemas/UnionTestPet" || properties["pets"].Items.Ref != "#/components/schemas/UnionTestPet" {
+		t.Errorf("Expected pet references, got %+v and %+v", properties["pet"], properties["pets"].Items)
+	}
+}
+
+func TestUnionsAreValidated(t *testing.T) {
+	registerUnionTestPet()
+
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.HandleFunc("/pets", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}, "", swaggo.RequestDetails{
+		Method:    "POST",
+		Requests:  []swaggo.RequestData{{Type: swaggo.BodySource, Data: swaggo.OneOf(UnionTestCat{}, UnionTestDog{})}},
+		Responses: []swaggo.ResponseData{{Code: 200, Data: swaggo.AnyOf(UnionTestCat{}, UnionTestDog{})}},
+	})
+
+	swaggoMux.HandleFunc("/owners", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}, "", swaggo.RequestDetails{
+		Method:   "POST",
+		Requests: []swaggo.RequestData{{Type: swaggo.BodySource, Data: UnionTestOwner{}}},
+	})
+
+	request := httptest.NewRequest(http.MethodPost, "/api/pets", strings.NewReader(`{"kind":"cat","lives":9}`))
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	request = httptest.NewRequest(http.MethodPost, "/api/pets", strings.NewReader(`{"kind":"cat","wings":2}`))
+	recorder = httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	var response swaggo.ValidationErrorResponse
+	json.Unmarshal(recorder.Body.Bytes(), &response)
+
+	if recorder.Code != http.StatusUnprocessableEntity || response.Errors[0].Message != "must match exactly one of UnionTestCat, UnionTestDog" {
+		t.Errorf("Expected oneOf error, got %d: %v", recorder.Code, response.Errors)
+	}
+
+	request = httptest.NewRequest(http.MethodPost, "/api/owners", strings.NewReader(`{"name":"a","pet":{"kind":"dog","breed":"pug"},"pets":[{"kind":"cat"}]}`))
+	recorder = httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	request = httptest.NewRequest(http.MethodPost, "/api/owners", strings.NewReader(`{"pet":{"kind":"dog","lives":1},"pets":[{"kind":"bird"}]}`))
+	recorder = httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	response = swaggo.ValidationErrorResponse{}
+	json.Unmarshal(recorder.Body.Bytes(), &response)
+
+	if recorder.Code != http.StatusUnprocessableEntity || len(response.Errors) != 2 || response.Errors[0].Field != "pet.lives" || response.Errors[1].Field != "pets[0].kind" {
+		t.Errorf("Expected discriminated errors, got %d: %v", recorder.Code, response.Errors)
+	}
+}
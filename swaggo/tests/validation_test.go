@@ -0,0 +1,271 @@
+package tests
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Pieeer1/Auto-SwagGo/swaggo"
+)
+
+type ValidationTestChild struct {
+	Name string `json:"name" required:"true"`
+}
+
+type ValidationTestBody struct {
+	Title    string                `json:"title" required:"true"`
+	Count    int                   `json:"count"`
+	Created  time.Time             `json:"created"`
+	Child    ValidationTestChild   `json:"child"`
+	Children []ValidationTestChild `json:"children"`
+	Ignored  string                `json:"-"`
+}
+
+func TestValidBodyReachesHandler(t *testing.T) {
+	handled := false
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{"v1"})
+
+	swaggoMux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		handled = true
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write(body)
+	}, "v1", swaggo.RequestDetails{
+		Method: "POST",
+		Requests: []swaggo.RequestData{
+			{
+				Type:     swaggo.BodySource,
+				Required: true,
+				Data:     ValidationTestBody{},
+			},
+		},
+	})
+
+	body := `{"title":"example","count":1,"created":"2024-01-01T00:00:00Z","child":{"name":"child"},"children":[{"name":"a"}]}`
+	request := httptest.NewRequest(http.MethodPost, "/api/v1/test", strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	if !handled {
+		t.Errorf("Expected handler to be called")
+	}
+
+	if recorder.Body.String() != body {
+		t.Errorf("Expected handler to read the original body, got %s", recorder.Body.String())
+	}
+}
+
+func TestInvalidBodyRespondsWith422(t *testing.T) {
+	handled := false
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{"v1"})
+
+	swaggoMux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		handled = true
+		w.WriteHeader(http.StatusOK)
+	}, "v1", swaggo.RequestDetails{
+		Method: "POST",
+		Requests: []swaggo.RequestData{
+			{
+				Type:     swaggo.BodySource,
+				Required: true,
+				Data:     ValidationTestBody{},
+			},
+		},
+	})
+
+	request := httptest.NewRequest(http.MethodPost, "/api/v1/test", strings.NewReader(`{"count":"one","created":"yesterday","child":{},"children":[{"name":1}],"extra":true}`))
+	request.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected 422, got %d", recorder.Code)
+	}
+
+	if handled {
+		t.Errorf("Expected handler not to be called")
+	}
+
+	var response swaggo.ValidationErrorResponse
+
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"title":            "is required",
+		"count":            "must be an integer",
+		"created":          "must be an RFC 3339 date-time",
+		"child.name":       "is required",
+		"children[0].name": "must be a string",
+		"extra":            "unknown field",
+	}
+
+	if len(response.Errors) != len(expected) {
+		t.Errorf("Expected %d errors, got %d: %+v", len(expected), len(response.Errors), response.Errors)
+	}
+
+	for _, validationError := range response.Errors {
+		if expected[validationError.Field] != validationError.Message {
+			t.Errorf("Unexpected error for %s: %s", validationError.Field, validationError.Message)
+		}
+	}
+}
+
+func TestMissingRequiredBodyRespondsWith422(t *testing.T) {
+	handled := false
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{"v1"})
+
+	swaggoMux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		handled = true
+		w.WriteHeader(http.StatusOK)
+	}, "v1", swaggo.RequestDetails{
+		Method: "POST",
+		Requests: []swaggo.RequestData{
+			{
+				Type:     swaggo.BodySource,
+				Required: true,
+				Data:     ValidationTestBody{},
+			},
+		},
+	})
+
+	request := httptest.NewRequest(http.MethodPost, "/api/v1/test", strings.NewReader(""))
+	request.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422, got %d", recorder.Code)
+	}
+
+	request = httptest.NewRequest(http.MethodPost, "/api/v1/test", strings.NewReader("{not json"))
+	request.Header.Set("Content-Type", "application/json")
+	recorder = httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422, got %d", recorder.Code)
+	}
+
+	if handled {
+		t.Errorf("Expected handler not to be called")
+	}
+}
+
+func TestNonJsonBodyIsNotValidated(t *testing.T) {
+	handled := false
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{"v1"})
+
+	swaggoMux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		handled = true
+		w.WriteHeader(http.StatusOK)
+	}, "v1", swaggo.RequestDetails{
+		Method: "POST",
+		Requests: []swaggo.RequestData{
+			{
+				Type:     swaggo.BodySource,
+				Required: true,
+				Data:     ValidationTestBody{},
+			},
+		},
+	})
+
+	request := httptest.NewRequest(http.MethodPost, "/api/v1/test", strings.NewReader("title=example"))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected 200, got %d", recorder.Code)
+	}
+
+	if !handled {
+		t.Errorf("Expected handler to be called")
+	}
+}
+
+func TestOversizedBodyRespondsWith413(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.ConfigureMaxBodySize(32)
+
+	handled := false
+
+	swaggoMux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		handled = true
+		w.WriteHeader(http.StatusOK)
+	}, "", swaggo.RequestDetails{
+		Method:   "POST",
+		Requests: []swaggo.RequestData{{Type: swaggo.BodySource, Data: ValidationTestChild{}}},
+	})
+
+	request := httptest.NewRequest(http.MethodPost, "/api/test", strings.NewReader(`{"name":"`+strings.Repeat("a", 64)+`"}`))
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusRequestEntityTooLarge || handled {
+		t.Errorf("Expected 413 without calling the handler, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	request = httptest.NewRequest(http.MethodPost, "/api/test", strings.NewReader(`{"name":"a"}`))
+	recorder = httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK || !handled {
+		t.Errorf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestUnvalidatedBodyIsNotLimited(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.ConfigureMaxBodySize(32)
+
+	var received int
+
+	swaggoMux.HandleFunc("/upload", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received = len(body)
+		w.WriteHeader(http.StatusOK)
+	}, "", swaggo.RequestDetails{Method: "POST"})
+
+	request := httptest.NewRequest(http.MethodPost, "/api/upload", strings.NewReader(strings.Repeat("a", 64)))
+	request.Header.Set("Content-Type", "application/octet-stream")
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK || received != 64 {
+		t.Errorf("Expected the whole upload to reach the handler, got %d after %d bytes", recorder.Code, received)
+	}
+}
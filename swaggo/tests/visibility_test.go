@@ -0,0 +1,157 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Pieeer1/Auto-SwagGo/swaggo"
+)
+
+type VisibilityTestProfile struct {
+	Secret string `json:"secret" writeOnly:"true"`
+	Bio    string `json:"bio"`
+}
+
+type VisibilityTestAccount struct {
+	Id        int                     `json:"id" readOnly:"true" required:"true"`
+	CreatedAt time.Time               `json:"createdAt" readOnly:"true"`
+	Email     string                  `json:"email" required:"true"`
+	Password  string                  `json:"password" format:"password"`
+	Profile   VisibilityTestProfile   `json:"profile"`
+	Friends   []VisibilityTestProfile `json:"friends"`
+}
+
+func TestReadOnlyAndWriteOnlyAreDocumented(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggo.Register(swaggoMux, http.MethodPost, "/accounts", "", func(ctx context.Context, account VisibilityTestAccount) (VisibilityTestAccount, error) {
+		account.Id = 1
+		return account, nil
+	})
+
+	doc, err := swaggoMux.MapDoc("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	properties := doc.Components.Schemas["VisibilityTestAccount"].Properties
+
+	if !properties["id"].ReadOnly || !properties["createdAt"].ReadOnly || properties["email"].ReadOnly {
+		t.Errorf("Expected id and createdAt to be read only, got %+v", properties)
+	}
+
+	if !properties["password"].WriteOnly || properties["password"].Format != "password" || properties["email"].WriteOnly {
+		t.Errorf("Expected password to be a write only password, got %+v", properties["password"])
+	}
+
+	if !doc.Components.Schemas["VisibilityTestProfile"].Properties["secret"].WriteOnly {
+		t.Errorf("Expected secret to be write only")
+	}
+}
+
+func TestReadOnlyAndWriteOnlyAreEnforced(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggo.Register(swaggoMux, http.MethodPost, "/accounts", "", func(ctx context.Context, account VisibilityTestAccount) (VisibilityTestAccount, error) {
+		account.Id = 1
+		return account, nil
+	})
+
+	request := httptest.NewRequest(http.MethodPost, "/api/accounts", strings.NewReader(`{"email":"a@b.co","password":"hunter2","profile":{"secret":"s","bio":"b"},"friends":[{"secret":"f","bio":"c"}]}`))
+	recorder := httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var response map[string]any
+
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := response["password"]; ok {
+		t.Errorf("Expected password to be stripped, got %v", response)
+	}
+
+	if response["id"] != 1.0 || response["email"] != "a@b.co" {
+		t.Errorf("Expected id and email, got %v", response)
+	}
+
+	profile := response["profile"].(map[string]any)
+	friend := response["friends"].([]any)[0].(map[string]any)
+
+	if _, ok := profile["secret"]; ok || profile["bio"] != "b" {
+		t.Errorf("Expected nested secret to be stripped, got %v", profile)
+	}
+
+	if _, ok := friend["secret"]; ok {
+		t.Errorf("Expected secret in slice items to be stripped, got %v", friend)
+	}
+
+	request = httptest.NewRequest(http.MethodPost, "/api/accounts", strings.NewReader(`{"id":5,"createdAt":"2024-01-01T00:00:00Z","email":"a@b.co"}`))
+	recorder = httptest.NewRecorder()
+	swaggoMux.ServeHTTP(recorder, request)
+
+	var validationResponse swaggo.ValidationErrorResponse
+
+	if err := json.Unmarshal(recorder.Body.Bytes(), &validationResponse); err != nil {
+		t.Fatal(err)
+	}
+
+	if recorder.Code != http.StatusUnprocessableEntity || len(validationResponse.Errors) != 2 || validationResponse.Errors[0].Message != "is read only" {
+		t.Errorf("Expected 2 read only errors, got %d: %v", recorder.Code, validationResponse.Errors)
+	}
+}
+
+type VisibilityTestAudit struct {
+	CreatedBy string `json:"createdBy"`
+}
+
+type VisibilityTestDocument struct {
+	Title string              `json:"title"`
+	Audit VisibilityTestAudit `json:"audit" readOnly:"true" description:"Set by the server"`
+	Owner VisibilityTestAudit `json:"owner"`
+}
+
+func TestReadOnlyReferenceIsWrappedInAllOf(t *testing.T) {
+	swaggoMux := swaggo.NewSwaggoMux(&swaggo.SwaggerInfo{
+		Title: "Test",
+	}, "http://test:8080", "/api", []string{})
+
+	swaggoMux.HandleFunc("/documents", nil, "", swaggo.RequestDetails{
+		Method:    "GET",
+		Responses: []swaggo.ResponseData{{Code: 200, Data: VisibilityTestDocument{}}},
+	})
+
+	doc, err := swaggoMux.MapDoc("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	audit := doc.Components.Schemas["VisibilityTestDocument"].Properties["audit"]
+
+	if audit.Ref != "" || len(audit.AllOf) != 1 || audit.AllOf[0].Ref != "#/components/schemas/VisibilityTestAudit" {
+		t.Errorf("Expected the reference to be wrapped in allOf, got %+v", audit)
+	}
+
+	if !audit.ReadOnly || audit.Description != "Set by the server" {
+		t.Errorf("Expected readOnly and description next to the allOf, got %+v", audit)
+	}
+
+	if owner := doc.Components.Schemas["VisibilityTestDocument"].Properties["owner"]; owner.Ref != "#/components/schemas/VisibilityTestAudit" || len(owner.AllOf) != 0 {
+		t.Errorf("Expected a plain reference without siblings, got %+v", owner)
+	}
+}
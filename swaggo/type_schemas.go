@@ -0,0 +1,139 @@
+package swaggo
+
+import (
+	"database/sql"
+	"encoding"
+	"encoding/json"
+	"math"
+	"math/big"
+	"net"
+	"net/url"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/Pieeer1/Auto-SwagGo/internal/ext"
+)
+
+// SchemaProvider lets a type describe its own schema instead of having its fields reflected.
+// Setting Nullable documents the type as nullable for the configured OpenAPI version.
+type SchemaProvider interface {
+	OpenApiSchema() Schema
+}
+
+var schemaProviderType = reflect.TypeOf((*SchemaProvider)(nil)).Elem()
+
+var typeSchemasMu sync.RWMutex
+
+// typeSchemas holds the schemas of types that do not serialize the way their fields suggest.
+// The sql.Null types are documented as their nullable value, matching how they are usually wrapped for json.
+var typeSchemas = map[reflect.Type]Schema{
+	reflect.TypeOf(time.Time{}):       {Type: "string", Format: "date-time"},
+	reflect.TypeOf(time.Duration(0)):  integerSchema("int64", nil, nil),
+	reflect.TypeOf(json.RawMessage{}): {},
+	reflect.TypeOf(net.IP{}):          {Type: "string"},
+	reflect.TypeOf(url.URL{}):         {Type: "string", Format: "uri"},
+	reflect.TypeOf(big.Int{}):         {Type: "integer"},
+	reflect.TypeOf(sql.NullString{}):  {Type: "string", Nullable: true},
+	reflect.TypeOf(sql.NullBool{}):    {Type: "boolean", Nullable: true},
+	reflect.TypeOf(sql.NullByte{}):    {Type: "integer", Format: "int32", Minimum: ext.ToPtr(0.0), Maximum: ext.ToPtr(float64(math.MaxUint8)), Nullable: true},
+	reflect.TypeOf(sql.NullInt16{}):   {Type: "integer", Format: "int32", Minimum: ext.ToPtr(float64(math.MinInt16)), Maximum: ext.ToPtr(float64(math.MaxInt16)), Nullable: true},
+	reflect.TypeOf(sql.NullInt32{}):   {Type: "integer", Format: "int32", Nullable: true},
+	reflect.TypeOf(sql.NullInt64{}):   {Type: "integer", Format: "int64", Nullable: true},
+	reflect.TypeOf(sql.NullFloat64{}): {Type: "number", Format: "double", Nullable: true},
+	reflect.TypeOf(sql.NullTime{}):    {Type: "string", Format: "date-time", Nullable: true},
+}
+
+// RegisterTypeSchema documents every use of a type with the given schema instead of reflecting it.
+// It applies to all muxes and replaces built in mappings, such as the one for time.Time.
+func RegisterTypeSchema(t reflect.Type, schema Schema) {
+	typeSchemasMu.Lock()
+	defer typeSchemasMu.Unlock()
+
+	typeSchemas[t] = schema
+}
+
+// customTypeSchema returns the schema a type provides itself, was registered for it or is implied by its marshaler.
+// A SchemaProvider method wins over the registry, which wins over the marshaler.
+func customTypeSchema(t reflect.Type) (Schema, bool) {
+	switch {
+	case t.Kind() == reflect.Interface || t.Kind() == reflect.Ptr:
+		return Schema{}, false
+	case t.Implements(schemaProviderType):
+		return reflect.Zero(t).Interface().(SchemaProvider).OpenApiSchema(), true
+	case reflect.PointerTo(t).Implements(schemaProviderType):
+		return reflect.New(t).Interface().(SchemaProvider).OpenApiSchema(), true
+	}
+
+	typeSchemasMu.RLock()
+	schema, ok := typeSchemas[t]
+	typeSchemasMu.RUnlock()
+
+	if ok {
+		return schema, true
+	}
+
+	if schema, ok := marshalerSchema(t); ok {
+		return schema, true
+	}
+
+	return pointerReceiverSchema(t)
+}
+
+var (
+	jsonMarshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// marshalerSchema documents types that serialize themselves, mirroring encoding/json which prefers json.Marshaler over
+// encoding.TextMarshaler. Text marshalers are strings, while the output of a json.Marshaler is unknown and accepts any value
+// until the type implements SchemaProvider or is registered. Only the method set of t counts here, since encoding/json
+// ignores pointer receiver methods on values it cannot address.
+func marshalerSchema(t reflect.Type) (Schema, bool) {
+	switch {
+	case t.Implements(jsonMarshalerType):
+		return Schema{}, true
+	case t.Implements(textMarshalerType):
+		return Schema{Type: "string"}, true
+	default:
+		return Schema{}, false
+	}
+}
+
+// pointerMarshalerSchema returns the marshaler schema of a pointer whose element only serializes itself through a
+// pointer receiver, which encoding/json always calls when the pointer is marshaled.
+func pointerMarshalerSchema(t reflect.Type) (Schema, bool) {
+	if _, custom := customTypeSchema(t.Elem()); custom {
+		return Schema{}, false
+	}
+
+	return marshalerSchema(t)
+}
+
+// unmarshalerSchema documents how encoding/json reads a type. It decodes into addressable values, so pointer receiver
+// unmarshalers apply to value fields too.
+func unmarshalerSchema(t reflect.Type) (Schema, bool) {
+	switch {
+	case reflect.PointerTo(t).Implements(jsonUnmarshalerType):
+		return Schema{}, true
+	case reflect.PointerTo(t).Implements(textUnmarshalerType):
+		return Schema{Type: "string"}, true
+	default:
+		return Schema{}, false
+	}
+}
+
+// pointerReceiverSchema documents a value type that reads and writes itself the same way through pointer receivers. The
+// validation middleware decodes into addressable values and WriteJson encodes through a pointer, so both call those methods.
+func pointerReceiverSchema(t reflect.Type) (Schema, bool) {
+	schema, unmarshals := unmarshalerSchema(t)
+	marshalSchema, marshals := marshalerSchema(reflect.PointerTo(t))
+
+	if !unmarshals || !marshals || schema.Type != marshalSchema.Type {
+		return Schema{}, false
+	}
+
+	return schema, true
+}
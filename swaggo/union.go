@@ -0,0 +1,190 @@
+package swaggo
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Pieeer1/Auto-SwagGo/internal/ext"
+)
+
+// Union describes data that is one of several types. It can be used as request or response data directly,
+// or registered for an interface or wrapper type so struct fields of that type are documented as the union.
+type Union struct {
+	anyOf        bool
+	variants     []any
+	propertyName string
+	mapping      map[string]any
+}
+
+// OneOf documents data that matches exactly one of the given variants.
+func OneOf(variants ...any) Union {
+	return Union{variants: variants}
+}
+
+// AnyOf documents data that matches at least one of the given variants.
+func AnyOf(variants ...any) Union {
+	return Union{anyOf: true, variants: variants}
+}
+
+// WithDiscriminator names the property that tells the variants apart. The mapping from property values to variants is optional,
+// without it the property value is expected to be the variant's schema name.
+func (u Union) WithDiscriminator(propertyName string, mapping map[string]any) Union {
+	u.propertyName = propertyName
+	u.mapping = mapping
+	return u
+}
+
+var typeUnions = map[reflect.Type]Union{}
+
+// RegisterUnion documents every use of a type, usually an interface implemented by the variants, as the given union.
+// It applies to all muxes.
+func RegisterUnion(t reflect.Type, union Union) {
+	typeSchemasMu.Lock()
+	defer typeSchemasMu.Unlock()
+
+	typeUnions[t] = union
+}
+
+func typeUnion(t reflect.Type) (Union, bool) {
+	typeSchemasMu.RLock()
+	defer typeSchemasMu.RUnlock()
+
+	union, ok := typeUnions[t]
+	return union, ok
+}
+
+func (u Union) keyword() string {
+	if u.anyOf {
+		return "anyOf"
+	}
+	return "oneOf"
+}
+
+// key identifies a union for deduplication, since every union shares the same Go type.
+func (u Union) key() string {
+	return fmt.Sprintf("%s%v%s", u.keyword(), ext.SliceMap(u.variants, func(variant any) reflect.Type {
+		return reflect.TypeOf(variant)
+	}), u.propertyName)
+}
+
+// dataKey identifies registered data for deduplication.
+func dataKey(data any) any {
+	if union, ok := data.(Union); ok {
+		return union.key()
+	}
+	return reflect.TypeOf(data)
+}
+
+// variantNames names the variants the way their component schemas are named, for messages that do not have a generator.
+func (u Union) variantNames() []string {
+	return ext.SliceMap(u.variants, func(variant any) string {
+		t := reflect.TypeOf(variant)
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if name, ok := typeSchemaName(t); ok {
+			return name
+		}
+		return ShortSchemaName(t)
+	})
+}
+
+func (g *schemaGenerator) unionSchema(union Union) (Schema, error) {
+	variants := make([]Schema, 0, len(union.variants))
+
+	for _, variant := range union.variants {
+		schema, err := g.dataSchema(variant)
+
+		if err != nil {
+			return Schema{}, err
+		}
+
+		variants = append(variants, schema)
+	}
+
+	schema := Schema{}
+
+	if union.anyOf {
+		schema.AnyOf = variants
+	} else {
+		schema.OneOf = variants
+	}
+
+	if union.propertyName == "" {
+		return schema, nil
+	}
+
+	schema.Discriminator = &Discriminator{PropertyName: union.propertyName}
+
+	if len(union.mapping) == 0 {
+		return schema, nil
+	}
+
+	schema.Discriminator.Mapping = make(map[string]string)
+
+	for _, value := range sortedKeys(union.mapping) {
+		mapped, err := g.dataSchema(union.mapping[value])
+
+		if err != nil {
+			return Schema{}, err
+		}
+
+		if mapped.Ref == "" {
+			return Schema{}, fmt.Errorf("discriminator value %s must map to a named struct", value)
+		}
+
+		schema.Discriminator.Mapping[value] = mapped.Ref
+	}
+
+	return schema, nil
+}
+
+// unionName names a union used as request body data, such as OneOfCatAndDog.
+func (g *schemaGenerator) unionName(union Union) (string, error) {
+	names := make([]string, 0, len(union.variants))
+
+	for _, variant := range union.variants {
+		name, err := g.componentName(reflect.TypeOf(variant))
+
+		if err != nil {
+			return "", err
+		}
+
+		names = append(names, name)
+	}
+
+	return fmt.Sprintf("%s%s", strings.ToUpper(union.keyword()[:1])+union.keyword()[1:], strings.Join(names, "And")), nil
+}
+
+// validateUnion checks a value against the variant selected by the discriminator, or otherwise against every variant.
+func validateUnion(path string, union Union, raw any) ValidationErrors {
+	if union.propertyName != "" && len(union.mapping) > 0 {
+		object, ok := raw.(map[string]any)
+		if !ok {
+			return ValidationErrors{{Field: path, Message: "must be an object"}}
+		}
+
+		value, _ := object[union.propertyName].(string)
+		variant, ok := union.mapping[value]
+
+		if !ok {
+			return ValidationErrors{{Field: joinFieldPath(path, union.propertyName), Message: fmt.Sprintf("must be one of %s", strings.Join(sortedKeys(union.mapping), ", "))}}
+		}
+
+		return validateValue(path, reflect.TypeOf(variant), raw)
+	}
+
+	matches := len(ext.Where(union.variants, func(variant any) bool {
+		return len(validateValue(path, reflect.TypeOf(variant), raw)) == 0
+	}))
+
+	switch {
+	case union.anyOf && matches == 0:
+		return ValidationErrors{{Field: path, Message: fmt.Sprintf("must match at least one of %s", strings.Join(union.variantNames(), ", "))}}
+	case !union.anyOf && matches != 1:
+		return ValidationErrors{{Field: path, Message: fmt.Sprintf("must match exactly one of %s", strings.Join(union.variantNames(), ", "))}}
+	default:
+		return nil
+	}
+}
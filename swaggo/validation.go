@@ -0,0 +1,383 @@
+package swaggo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Pieeer1/Auto-SwagGo/internal/ext"
+)
+
+type ValidationError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type ValidationErrors []ValidationError
+
+func (v ValidationErrors) Error() string {
+	return strings.Join(ext.SliceMap(v, func(validationError ValidationError) string {
+		if validationError.Field == "" {
+			return validationError.Message
+		}
+		return fmt.Sprintf("%s: %s", validationError.Field, validationError.Message)
+	}), "; ")
+}
+
+type ValidationErrorResponse struct {
+	Errors ValidationErrors `json:"errors"`
+}
+
+func writeValidationErrors(w http.ResponseWriter, validationErrors ValidationErrors) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	json.NewEncoder(w).Encode(ValidationErrorResponse{Errors: validationErrors})
+}
+
+// validateRequest checks the incoming parameters and body against the registered request data for the matched method.
+// The request body is buffered and restored so the handler can still read it. A body over the size limit is returned as the
+// *http.MaxBytesError of the reader, any other failure as ValidationErrors.
+func validateRequest(r *http.Request, requestDetails RequestDetails, maxBodySize int64) error {
+	validationErrors := make(ValidationErrors, 0)
+
+	for _, requestData := range ext.Where(requestDetails.Requests, func(requestData RequestData) bool {
+		return requestData.Type != BodySource && requestData.Data != nil
+	}) {
+		t, _, err := rawReflect(requestData.Data)
+
+		if err != nil || t.Kind() != reflect.Struct {
+			continue
+		}
+
+		validationErrors = append(validationErrors, bindParameters(r, requestData.Type, reflect.New(t).Elem())...)
+	}
+
+	for _, requestData := range ext.Where(requestDetails.Requests, func(requestData RequestData) bool {
+		return requestData.Type == BodySource && requestData.Data != nil
+	}) {
+		if !shouldValidateBody(r, requestData) {
+			continue
+		}
+
+		var body []byte
+
+		if r.Body != nil {
+			readBody, err := readLimitedBody(r.Body, maxBodySize)
+			var maxBytesError *http.MaxBytesError
+			if errors.As(err, &maxBytesError) {
+				return maxBytesError
+			}
+			if err != nil {
+				return ValidationErrors{{Message: fmt.Sprintf("unable to read request body: %s", err.Error())}}
+			}
+			r.Body.Close()
+			r.Body = io.NopCloser(bytes.NewReader(readBody))
+			body = readBody
+		}
+
+		if len(bytes.TrimSpace(body)) == 0 {
+			if requestData.Required {
+				validationErrors = append(validationErrors, ValidationError{Message: "request body is required"})
+			}
+			continue
+		}
+
+		validationErrors = append(validationErrors, validateBody(requestData.Data, body)...)
+	}
+
+	if len(validationErrors) > 0 {
+		return validationErrors
+	}
+
+	return nil
+}
+
+// readLimitedBody reads a body that is buffered for validation, failing with a *http.MaxBytesError once it exceeds maxBodySize.
+func readLimitedBody(body io.Reader, maxBodySize int64) ([]byte, error) {
+	if maxBodySize <= 0 {
+		return io.ReadAll(body)
+	}
+
+	readBody, err := io.ReadAll(io.LimitReader(body, maxBodySize+1))
+
+	if err == nil && int64(len(readBody)) > maxBodySize {
+		return nil, &http.MaxBytesError{Limit: maxBodySize}
+	}
+
+	return readBody, err
+}
+
+func shouldValidateBody(r *http.Request, requestData RequestData) bool {
+	if r.Header.Get("Content-Type") != "" {
+		return isJsonContentType(r.Header.Get("Content-Type"))
+	}
+
+	if len(requestData.ContentType) == 0 {
+		return true // default to application/json if no type is given
+	}
+
+	return len(ext.Where(requestData.ContentType, isJsonContentType)) > 0
+}
+
+func isJsonContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"))
+}
+
+func validateBody(data any, body []byte) ValidationErrors {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var raw any
+
+	if err := decoder.Decode(&raw); err != nil {
+		return ValidationErrors{{Message: fmt.Sprintf("invalid json: %s", err.Error())}}
+	}
+
+	if decoder.More() {
+		return ValidationErrors{{Message: "invalid json: unexpected data after top-level value"}}
+	}
+
+	if union, ok := data.(Union); ok {
+		return validateUnion("", union, raw)
+	}
+
+	return validateValue("", reflect.TypeOf(data), raw)
+}
+
+func validateValue(path string, t reflect.Type, raw any) ValidationErrors {
+	if schema, ok := customTypeSchema(t); ok {
+		return validateSchemaValue(path, schema, raw)
+	}
+
+	if schema, ok := unmarshalerSchema(t); ok {
+		return validateSchemaValue(path, schema, raw)
+	}
+
+	if raw == nil {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+			return nil
+		default:
+			return ValidationErrors{{Field: path, Message: "must not be null"}}
+		}
+	}
+
+	if union, ok := typeUnion(t); ok {
+		return validateUnion(path, union, raw)
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		if schema, ok := pointerMarshalerSchema(t); ok {
+			return validateSchemaValue(path, schema, raw)
+		}
+		return validateValue(path, t.Elem(), raw)
+	case reflect.Interface:
+		return nil
+	case reflect.String:
+		if _, ok := raw.(string); !ok {
+			return ValidationErrors{{Field: path, Message: "must be a string"}}
+		}
+	case reflect.Bool:
+		if _, ok := raw.(bool); !ok {
+			return ValidationErrors{{Field: path, Message: "must be a boolean"}}
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number, ok := raw.(json.Number)
+		if !ok {
+			return ValidationErrors{{Field: path, Message: "must be an integer"}}
+		}
+		if _, err := strconv.ParseInt(number.String(), 10, t.Bits()); err != nil {
+			return ValidationErrors{{Field: path, Message: "must be an integer"}}
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number, ok := raw.(json.Number)
+		if !ok {
+			return ValidationErrors{{Field: path, Message: "must be a non-negative integer"}}
+		}
+		if _, err := strconv.ParseUint(number.String(), 10, t.Bits()); err != nil {
+			return ValidationErrors{{Field: path, Message: "must be a non-negative integer"}}
+		}
+	case reflect.Float32, reflect.Float64:
+		number, ok := raw.(json.Number)
+		if !ok {
+			return ValidationErrors{{Field: path, Message: "must be a number"}}
+		}
+		if _, err := strconv.ParseFloat(number.String(), t.Bits()); err != nil {
+			return ValidationErrors{{Field: path, Message: "must be a number"}}
+		}
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 { // byte slices are base64 strings
+			if _, ok := raw.(string); !ok {
+				return ValidationErrors{{Field: path, Message: "must be a base64 encoded string"}}
+			}
+			return nil
+		}
+		items, ok := raw.([]any)
+		if !ok {
+			return ValidationErrors{{Field: path, Message: "must be an array"}}
+		}
+		validationErrors := make(ValidationErrors, 0)
+		for i, item := range items {
+			validationErrors = append(validationErrors, validateValue(fmt.Sprintf("%s[%d]", path, i), t.Elem(), item)...)
+		}
+		return validationErrors
+	case reflect.Map:
+		object, ok := raw.(map[string]any)
+		if !ok {
+			return ValidationErrors{{Field: path, Message: "must be an object"}}
+		}
+		validationErrors := make(ValidationErrors, 0)
+		for _, key := range sortedKeys(object) {
+			validationErrors = append(validationErrors, validateValue(joinFieldPath(path, key), t.Elem(), object[key])...)
+		}
+		return validationErrors
+	case reflect.Struct:
+		object, ok := raw.(map[string]any)
+		if !ok {
+			return ValidationErrors{{Field: path, Message: "must be an object"}}
+		}
+		return validateObject(path, t, object)
+	}
+
+	return typeEnumConstraints(t).check(path, raw)
+}
+
+// validateSchemaValue checks a value against the documented schema of a type that is not reflected, such as time.Time.
+// Only the type and format are checked since the type decodes the value itself.
+func validateSchemaValue(path string, schema Schema, raw any) ValidationErrors {
+	if raw == nil {
+		if schema.Nullable || schema.Type == "" {
+			return nil
+		}
+		return ValidationErrors{{Field: path, Message: "must not be null"}}
+	}
+
+	switch schema.Type {
+	case "string":
+		str, ok := raw.(string)
+		if !ok && schema.Format != "" {
+			return ValidationErrors{{Field: path, Message: fmt.Sprintf("must be a %s string", schema.Format)}}
+		}
+		if !ok {
+			return ValidationErrors{{Field: path, Message: "must be a string"}}
+		}
+		if schema.Format == "date-time" && !isValidFormat(schema.Format, str) {
+			return ValidationErrors{{Field: path, Message: "must be an RFC 3339 date-time"}}
+		}
+		if !isValidFormat(schema.Format, str) {
+			return ValidationErrors{{Field: path, Message: fmt.Sprintf("must be a valid %s", schema.Format)}}
+		}
+	case "boolean":
+		if _, ok := raw.(bool); !ok {
+			return ValidationErrors{{Field: path, Message: "must be a boolean"}}
+		}
+	case "integer":
+		number, ok := raw.(json.Number)
+		if !ok {
+			return ValidationErrors{{Field: path, Message: "must be an integer"}}
+		}
+		if _, ok := new(big.Int).SetString(number.String(), 10); !ok {
+			return ValidationErrors{{Field: path, Message: "must be an integer"}}
+		}
+	case "number":
+		if _, ok := raw.(json.Number); !ok {
+			return ValidationErrors{{Field: path, Message: "must be a number"}}
+		}
+	case "array":
+		if _, ok := raw.([]any); !ok {
+			return ValidationErrors{{Field: path, Message: "must be an array"}}
+		}
+	case "object":
+		if _, ok := raw.(map[string]any); !ok {
+			return ValidationErrors{{Field: path, Message: "must be an object"}}
+		}
+	}
+
+	return nil
+}
+
+func validateObject(path string, t reflect.Type, object map[string]any) ValidationErrors {
+	validationErrors := make(ValidationErrors, 0)
+	fields := jsonFields(t)
+	matchedKeys := make(map[string]bool)
+
+	for _, field := range fields {
+		key, present := matchJsonKey(object, field.name)
+
+		if present {
+			matchedKeys[key] = true
+		}
+
+		if present && isReadOnly(field.field) {
+			validationErrors = append(validationErrors, ValidationError{Field: joinFieldPath(path, field.name), Message: "is read only"})
+			continue
+		}
+
+		if !present || object[key] == nil {
+			if field.field.Tag.Get("required") == "true" && !isReadOnly(field.field) {
+				validationErrors = append(validationErrors, ValidationError{Field: joinFieldPath(path, field.name), Message: "is required"})
+			}
+			if !present {
+				continue
+			}
+		}
+
+		fieldErrors := validateValue(joinFieldPath(path, field.name), field.field.Type, object[key])
+
+		if len(fieldErrors) == 0 {
+			// the constraint tags were checked when the route was registered
+			constraints, _ := parseFieldConstraints(field.field)
+			fieldErrors = constraints.check(joinFieldPath(path, field.name), object[key])
+		}
+
+		validationErrors = append(validationErrors, fieldErrors...)
+	}
+
+	for _, key := range sortedKeys(object) {
+		if !matchedKeys[key] {
+			validationErrors = append(validationErrors, ValidationError{Field: joinFieldPath(path, key), Message: "unknown field"})
+		}
+	}
+
+	return validationErrors
+}
+
+// matchJsonKey mirrors encoding/json, preferring an exact key match and falling back to a case-insensitive one.
+func matchJsonKey(object map[string]any, name string) (string, bool) {
+	if _, ok := object[name]; ok {
+		return name, true
+	}
+	for _, key := range sortedKeys(object) {
+		if strings.EqualFold(key, name) {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+func joinFieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return fmt.Sprintf("%s.%s", path, name)
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
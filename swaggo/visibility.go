@@ -0,0 +1,89 @@
+package swaggo
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+// isReadOnly reports whether a property is only sent by the server, such as an id or a timestamp.
+func isReadOnly(field reflect.StructField) bool {
+	return field.Tag.Get("readOnly") == "true"
+}
+
+// isWriteOnly reports whether a property is only sent by the client. Password fields are write only unless tagged otherwise.
+func isWriteOnly(field reflect.StructField) bool {
+	if field.Tag.Get("writeOnly") != "" {
+		return field.Tag.Get("writeOnly") == "true"
+	}
+	return field.Tag.Get("format") == "password"
+}
+
+// hasWriteOnly reports whether encoding a type can emit a write only property, so responses without any skip the rewrite.
+func hasWriteOnly(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return hasWriteOnly(t.Elem(), seen)
+	case reflect.Struct:
+		if _, custom := customTypeSchema(t); custom {
+			return false
+		}
+		for _, field := range jsonFields(t) {
+			if isWriteOnly(field.field) || hasWriteOnly(field.field.Type, seen) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// withoutWriteOnly removes the write only properties from an encoded response body.
+func withoutWriteOnly(t reflect.Type, body []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var raw any
+
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	stripWriteOnly(t, raw)
+
+	return json.Marshal(raw)
+}
+
+func stripWriteOnly(t reflect.Type, raw any) {
+	switch t.Kind() {
+	case reflect.Ptr:
+		stripWriteOnly(t.Elem(), raw)
+	case reflect.Slice, reflect.Array:
+		items, _ := raw.([]any)
+		for _, item := range items {
+			stripWriteOnly(t.Elem(), item)
+		}
+	case reflect.Map:
+		object, _ := raw.(map[string]any)
+		for _, value := range object {
+			stripWriteOnly(t.Elem(), value)
+		}
+	case reflect.Struct:
+		object, ok := raw.(map[string]any)
+		if _, custom := customTypeSchema(t); custom || !ok {
+			return
+		}
+		for _, field := range jsonFields(t) {
+			if isWriteOnly(field.field) {
+				delete(object, field.name)
+			} else if value, ok := object[field.name]; ok {
+				stripWriteOnly(field.field.Type, value)
+			}
+		}
+	}
+}